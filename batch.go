@@ -0,0 +1,192 @@
+package tarantool
+
+import (
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Batch is a set of requests that are encoded into a single buffer and
+// written to a connection with a single flush. It reduces the overhead of
+// sending many requests, for example, for bulk inserts.
+//
+// Interceptors and a retry policy of the connection are not applied to
+// requests of a batch.
+//
+// The Batch is not safe for concurrent use.
+type Batch struct {
+	conn     *Connection
+	requests []Request
+	futures  []*Future
+}
+
+// BatchError is returned by Batch.WaitAll if some of the requests failed.
+type BatchError struct {
+	// Errors contains an error for each request of the batch in the order
+	// of adding. The error is nil if the request succeeded.
+	Errors []error
+}
+
+// Error returns a description of the errors.
+func (e *BatchError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d batch requests failed, first error: %s",
+		failed, len(e.Errors), first)
+}
+
+// Unwrap returns errors of the failed requests.
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// NewBatch creates a new empty Batch for the connection.
+func (conn *Connection) NewBatch() *Batch {
+	return &Batch{conn: conn}
+}
+
+// Add adds requests to the batch.
+func (b *Batch) Add(reqs ...Request) *Batch {
+	b.requests = append(b.requests, reqs...)
+	return b
+}
+
+// Len returns a number of requests added to the batch.
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Send sends all requests of the batch and returns futures in the order of
+// adding. The batch becomes empty after the call, so it could be reused.
+func (b *Batch) Send() []*Future {
+	b.futures = b.conn.sendBatch(b.requests)
+	b.requests = nil
+	return b.futures
+}
+
+// Futures returns futures of the last sent requests.
+func (b *Batch) Futures() []*Future {
+	return b.futures
+}
+
+// WaitAll waits for all futures of the last sent requests. It returns
+// *BatchError if some of the requests failed.
+func (b *Batch) WaitAll() error {
+	errs := make([]error, len(b.futures))
+	failed := false
+	for i, fut := range b.futures {
+		resp, err := fut.GetResponse()
+		if err == nil && resp.Header().Error != ErrorNo {
+			_, err = resp.Decode()
+		}
+		if err != nil {
+			errs[i] = err
+			failed = true
+		}
+	}
+	if failed {
+		return &BatchError{Errors: errs}
+	}
+	return nil
+}
+
+// sendBatch sends requests with a minimal number of flushes.
+func (conn *Connection) sendBatch(reqs []Request) []*Future {
+	futures := make([]*Future, len(reqs))
+
+	var pending []*Future
+	var pendingReqs []Request
+	// A rate limit with RLimitWait blocks a creation of a future until
+	// responses for previous requests are received, so pending requests
+	// must be written before the wait.
+	flush := func() {
+		conn.putFutures(pending, pendingReqs, ignoreStreamId)
+		pending, pendingReqs = pending[:0], pendingReqs[:0]
+	}
+	for i, req := range reqs {
+		if connectedReq, ok := req.(ConnectedRequest); ok {
+			if connectedReq.Conn() != conn {
+				fut := NewFuture(req)
+				fut.SetError(errUnknownRequest)
+				futures[i] = fut
+				continue
+			}
+		}
+
+		fut, ok := conn.startFuture(req, flush)
+		futures[i] = fut
+		if ok {
+			pending = append(pending, fut)
+			pendingReqs = append(pendingReqs, req)
+		}
+	}
+	flush()
+	return futures
+}
+
+// putFutures encodes requests of the futures into a single shard buffer, so
+// they are written to the connection with a single flush.
+func (conn *Connection) putFutures(futures []*Future, reqs []Request, streamId uint64) {
+	if len(futures) == 0 {
+		return
+	}
+
+	type packError struct {
+		fut *Future
+		err error
+	}
+	var packErrs []packError
+	var async []uint32
+
+	shardn := futures[0].requestId & (conn.opts.Concurrency - 1)
+	shard := &conn.shard[shardn]
+	shard.bufmut.Lock()
+	firstWritten := shard.buf.Len() == 0
+	if shard.buf.Cap() == 0 {
+		shard.buf.b = make([]byte, 0, 128)
+		shard.enc = msgpack.NewEncoder(&shard.buf)
+	}
+	for i, fut := range futures {
+		select {
+		case <-fut.done:
+			continue
+		default:
+		}
+		blen := shard.buf.Len()
+		schemaVersion, err := pack(&shard.buf, shard.enc, fut.requestId, reqs[i],
+			streamId, conn.schemaResolver, &shard.versioned)
+		fut.setSent(streamId, schemaVersion)
+		if err != nil {
+			shard.buf.Trunc(blen)
+			packErrs = append(packErrs, packError{fut, err})
+		} else if reqs[i].Async() {
+			async = append(async, fut.requestId)
+		}
+	}
+	written := shard.buf.Len() != 0
+	shard.bufmut.Unlock()
+
+	if firstWritten && written {
+		conn.dirtyShard <- shardn
+	}
+	for _, packErr := range packErrs {
+		conn.packFailed(packErr.fut, packErr.err)
+	}
+	for _, reqid := range async {
+		conn.asyncSent(reqid)
+	}
+}
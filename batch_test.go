@@ -0,0 +1,209 @@
+package tarantool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+// handleBatchInsert sets a handler that accepts inserts of tuples with a
+// positive first field.
+func handleBatchInsert(server *mockserver.Server) {
+	server.Handle(iproto.IPROTO_INSERT, func(req *mockserver.Request) ([]interface{}, error) {
+		tuple := req.Tuple()
+		if id, ok := tuple[0].(int8); !ok || id <= 0 {
+			return nil, Error{Code: iproto.ER_FIELD_TYPE, Msg: "invalid id"}
+		}
+		return []interface{}{tuple}, nil
+	})
+}
+
+func TestBatch(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleBatchInsert(server)
+	conn := server.Connect(t, Opts{})
+
+	batch := conn.NewBatch()
+	for i := 1; i <= 5; i++ {
+		batch.Add(NewInsertRequest(512).Tuple([]interface{}{i, "value"}))
+	}
+	require.Equal(t, 5, batch.Len())
+
+	futures := batch.Send()
+	require.Len(t, futures, 5)
+	assert.Equal(t, 0, batch.Len())
+	assert.Equal(t, futures, batch.Futures())
+	require.NoError(t, batch.WaitAll())
+
+	for i, fut := range futures {
+		data, err := fut.Get()
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{[]interface{}{int8(i + 1), "value"}}, data)
+	}
+
+	var ids []uint64
+	for _, req := range server.Requests() {
+		if req.Type == iproto.IPROTO_INSERT {
+			ids = append(ids, req.Sync)
+		}
+	}
+	require.Len(t, ids, 5)
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i-1], ids[i])
+	}
+}
+
+func TestBatch_errors(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleBatchInsert(server)
+	conn := server.Connect(t, Opts{})
+	other := server.Connect(t, Opts{})
+
+	batch := conn.NewBatch().
+		Add(NewInsertRequest(512).Tuple([]interface{}{1})).
+		Add(NewInsertRequest(512).Tuple([]interface{}{-1})).
+		Add(NewInsertRequest(512).Tuple([]interface{}{2})).
+		Add(NewInsertRequest(512).Tuple([]interface{}{3}).Context(canceledCtx())).
+		Add(NewCallRequest("f").Args([]interface{}{make(chan int)})).
+		Add(NewUnprepareRequest(&Prepared{Conn: other}))
+	batch.Send()
+
+	err := batch.WaitAll()
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Len(t, batchErr.Errors, 6)
+	assert.NoError(t, batchErr.Errors[0])
+	assert.NoError(t, batchErr.Errors[2])
+
+	var tntErr Error
+	require.ErrorAs(t, batchErr.Errors[1], &tntErr)
+	assert.Equal(t, iproto.ER_FIELD_TYPE, tntErr.Code)
+	require.ErrorAs(t, err, &tntErr)
+	assert.Error(t, batchErr.Errors[3])
+	assert.Error(t, batchErr.Errors[4])
+	assert.Error(t, batchErr.Errors[5])
+	assert.Contains(t, err.Error(), "4 of 6 batch requests failed")
+}
+
+func TestBatch_rateLimit(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleBatchInsert(server)
+	conn := server.Connect(t, Opts{
+		RateLimit:    2,
+		RLimitAction: RLimitWait,
+	})
+
+	batch := conn.NewBatch()
+	for i := 1; i <= 7; i++ {
+		batch.Add(NewInsertRequest(512).Tuple([]interface{}{i}))
+	}
+	done := make(chan error, 1)
+	go func() {
+		batch.Send()
+		done <- batch.WaitAll()
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("batch is blocked by the rate limit")
+	}
+}
+
+func TestBatch_rateLimitConcurrent(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleBatchInsert(server)
+	conn := server.Connect(t, Opts{
+		RateLimit:    3,
+		RLimitAction: RLimitWait,
+	})
+
+	const batches = 2
+	done := make(chan error, batches+1)
+	for b := 0; b < batches; b++ {
+		go func() {
+			batch := conn.NewBatch()
+			for i := 1; i <= 10; i++ {
+				batch.Add(NewInsertRequest(512).Tuple([]interface{}{i}))
+			}
+			batch.Send()
+			done <- batch.WaitAll()
+		}()
+	}
+	go func() {
+		for i := 1; i <= 10; i++ {
+			_, err := conn.Do(NewInsertRequest(512).Tuple([]interface{}{i})).Get()
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	for i := 0; i < batches+1; i++ {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("requests are blocked by the rate limit")
+		}
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	errFirst := errors.New("first")
+	err := &BatchError{Errors: []error{nil, errFirst, nil, errors.New("second")}}
+	assert.Equal(t, "2 of 4 batch requests failed, first error: first", err.Error())
+	assert.ErrorIs(t, err, errFirst)
+}
+
+func canceledCtx() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func BenchmarkBatchInsert(b *testing.B) {
+	const batchSize = 100
+
+	server := mockserver.StartTest(b, mockserver.Opts{})
+	handleBatchInsert(server)
+	conn := server.Connect(b, Opts{})
+
+	b.Run("Do", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			futures := make([]*Future, batchSize)
+			for j := range futures {
+				futures[j] = conn.Do(NewInsertRequest(512).Tuple([]interface{}{1}))
+			}
+			for _, fut := range futures {
+				if _, err := fut.GetResponse(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Batch", func(b *testing.B) {
+		batch := conn.NewBatch()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < batchSize; j++ {
+				batch.Add(NewInsertRequest(512).Tuple([]interface{}{1}))
+			}
+			batch.Send()
+			if err := batch.WaitAll(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package box
+
+import (
+	"context"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	checkpointConfigLua = `return {
+    checkpoint_interval = box.cfg.checkpoint_interval,
+    checkpoint_count = box.cfg.checkpoint_count,
+    checkpoint_wal_threshold = box.cfg.checkpoint_wal_threshold,
+}
+`
+	setCheckpointConfigLua = `box.cfg(...)`
+)
+
+// SnapshotRequest makes a checkpoint with box.snapshot().
+type SnapshotRequest struct {
+	callRequest
+}
+
+// NewSnapshotRequest returns a new box.snapshot() request.
+func NewSnapshotRequest() *SnapshotRequest {
+	return &SnapshotRequest{newCallRequest("box.snapshot")}
+}
+
+// Context sets a passed context to the request.
+func (req *SnapshotRequest) Context(ctx context.Context) *SnapshotRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// BackupStartRequest starts a backup with box.backup.start(). Files of the
+// backup are not removed by the garbage collector until the backup is
+// stopped. The response data could be decoded into BackupStartResponse.
+type BackupStartRequest struct {
+	callRequest
+}
+
+// NewBackupStartRequest returns a new box.backup.start() request. The
+// backup contains files of the n-th checkpoint before the last one, so 0
+// means the last checkpoint.
+func NewBackupStartRequest(n uint) *BackupStartRequest {
+	if n == 0 {
+		return &BackupStartRequest{newCallRequest("box.backup.start")}
+	}
+	return &BackupStartRequest{newCallRequest("box.backup.start", n)}
+}
+
+// Context sets a passed context to the request.
+func (req *BackupStartRequest) Context(ctx context.Context) *BackupStartRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// BackupStartResponse is a response to BackupStartRequest.
+type BackupStartResponse struct {
+	// Files are absolute paths of snapshot, WAL and vinyl files to copy.
+	Files []string
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *BackupStartResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	r.Files = nil
+	return decodeSingle(d, &r.Files)
+}
+
+// BackupStopRequest stops a backup with box.backup.stop().
+type BackupStopRequest struct {
+	callRequest
+}
+
+// NewBackupStopRequest returns a new box.backup.stop() request.
+func NewBackupStopRequest() *BackupStopRequest {
+	return &BackupStopRequest{newCallRequest("box.backup.stop")}
+}
+
+// Context sets a passed context to the request.
+func (req *BackupStopRequest) Context(ctx context.Context) *BackupStopRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// CheckpointConfig contains checkpoint settings of box.cfg.
+type CheckpointConfig struct {
+	// Interval is a period of time (in seconds) between checkpoints. Zero
+	// disables automatic checkpoints.
+	Interval float64 `msgpack:"checkpoint_interval"`
+	// Count is a maximum count of checkpoints to keep.
+	Count uint64 `msgpack:"checkpoint_count"`
+	// WALThreshold is a size of WAL files (in bytes) that triggers a
+	// checkpoint.
+	WALThreshold uint64 `msgpack:"checkpoint_wal_threshold"`
+}
+
+// CheckpointConfigResponse is a response to CheckpointConfigRequest.
+type CheckpointConfigResponse struct {
+	CheckpointConfig CheckpointConfig
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *CheckpointConfigResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	return decodeSingle(d, &r.CheckpointConfig)
+}
+
+// CheckpointConfigRequest gets checkpoint settings of box.cfg.
+type CheckpointConfigRequest struct {
+	evalRequest
+}
+
+// NewCheckpointConfigRequest returns a new request to get checkpoint
+// settings.
+func NewCheckpointConfigRequest() *CheckpointConfigRequest {
+	return &CheckpointConfigRequest{newEvalRequest(checkpointConfigLua)}
+}
+
+// Context sets a passed context to the request.
+func (req *CheckpointConfigRequest) Context(ctx context.Context) *CheckpointConfigRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SetCheckpointConfigRequest sets checkpoint settings with box.cfg{}.
+type SetCheckpointConfigRequest struct {
+	evalRequest
+}
+
+// NewSetCheckpointConfigRequest returns a new request to set checkpoint
+// settings. All the settings are set, so a current configuration could be
+// received with CheckpointConfigRequest and changed.
+func NewSetCheckpointConfigRequest(cfg CheckpointConfig) *SetCheckpointConfigRequest {
+	return &SetCheckpointConfigRequest{newEvalRequest(setCheckpointConfigLua, cfg)}
+}
+
+// Context sets a passed context to the request.
+func (req *SetCheckpointConfigRequest) Context(
+	ctx context.Context) *SetCheckpointConfigRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// Snapshot makes a checkpoint.
+func (b *Box) Snapshot() error {
+	return do(b.conn, NewSnapshotRequest())
+}
+
+// BackupStart starts a backup of the n-th checkpoint before the last one
+// and returns files to copy.
+func (b *Box) BackupStart(n uint) ([]string, error) {
+	var resp BackupStartResponse
+	if err := b.conn.Do(NewBackupStartRequest(n)).GetTyped(&resp); err != nil {
+		return nil, err
+	}
+	return resp.Files, nil
+}
+
+// BackupStop stops a backup.
+func (b *Box) BackupStop() error {
+	return do(b.conn, NewBackupStopRequest())
+}
+
+// CheckpointConfig returns checkpoint settings of box.cfg.
+func (b *Box) CheckpointConfig() (CheckpointConfig, error) {
+	var resp CheckpointConfigResponse
+	err := b.conn.Do(NewCheckpointConfigRequest()).GetTyped(&resp)
+	return resp.CheckpointConfig, err
+}
+
+// SetCheckpointConfig sets checkpoint settings of box.cfg.
+func (b *Box) SetCheckpointConfig(cfg CheckpointConfig) error {
+	return do(b.conn, NewSetCheckpointConfigRequest(cfg))
+}
@@ -0,0 +1,102 @@
+package box_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestBox_SnapshotAndBackup(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+
+	var mutex sync.Mutex
+	var calls []string
+	var startArgs []interface{}
+	server.HandleCall("box.snapshot", func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls = append(calls, req.FunctionName())
+		return []interface{}{"ok"}, nil
+	})
+	server.HandleCall("box.backup.start", func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls = append(calls, req.FunctionName())
+		startArgs = req.Tuple()
+		return []interface{}{[]string{
+			"/var/lib/tarantool/00000000000000000008.snap",
+			"/var/lib/tarantool/00000000000000000008.xlog",
+		}}, nil
+	})
+	server.HandleCall("box.backup.stop", func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls = append(calls, req.FunctionName())
+		return nil, nil
+	})
+
+	b := box.New(conn)
+	require.NoError(t, b.Snapshot())
+	files, err := b.BackupStart(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/var/lib/tarantool/00000000000000000008.snap",
+		"/var/lib/tarantool/00000000000000000008.xlog",
+	}, files)
+	require.NoError(t, b.BackupStop())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{"box.snapshot", "box.backup.start", "box.backup.stop"}, calls)
+	assert.Equal(t, []interface{}{int8(1)}, startArgs)
+}
+
+func TestBox_CheckpointConfig(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+
+	var mutex sync.Mutex
+	cfg := map[string]interface{}{
+		"checkpoint_interval":      3600.0,
+		"checkpoint_count":         2,
+		"checkpoint_wal_threshold": uint64(1e18),
+	}
+	server.Handle(iproto.IPROTO_EVAL, func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if args := req.Tuple(); len(args) > 0 {
+			for key, value := range args[0].(map[string]interface{}) {
+				cfg[key] = value
+			}
+			return nil, nil
+		}
+		return []interface{}{cfg}, nil
+	})
+
+	b := box.New(conn)
+	current, err := b.CheckpointConfig()
+	require.NoError(t, err)
+	assert.Equal(t, box.CheckpointConfig{
+		Interval:     3600,
+		Count:        2,
+		WALThreshold: 1e18,
+	}, current)
+
+	current.Interval = 0
+	current.Count = 5
+	require.NoError(t, b.SetCheckpointConfig(current))
+
+	current, err = b.CheckpointConfig()
+	require.NoError(t, err)
+	assert.Equal(t, box.CheckpointConfig{
+		Interval:     0,
+		Count:        5,
+		WALThreshold: 1e18,
+	}, current)
+}
@@ -0,0 +1,140 @@
+package box
+
+import (
+	"context"
+	"time"
+)
+
+// CtlPromoteRequest makes the instance a leader of the replica set with
+// box.ctl.promote(). It starts an election if it is enabled or takes the
+// synchronous queue otherwise.
+type CtlPromoteRequest struct {
+	callRequest
+}
+
+// NewCtlPromoteRequest returns a new box.ctl.promote() request.
+func NewCtlPromoteRequest() *CtlPromoteRequest {
+	return &CtlPromoteRequest{newCallRequest("box.ctl.promote")}
+}
+
+// Context sets a passed context to the request.
+func (req *CtlPromoteRequest) Context(ctx context.Context) *CtlPromoteRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// CtlDemoteRequest resigns the leadership of the instance with
+// box.ctl.demote().
+type CtlDemoteRequest struct {
+	callRequest
+}
+
+// NewCtlDemoteRequest returns a new box.ctl.demote() request.
+func NewCtlDemoteRequest() *CtlDemoteRequest {
+	return &CtlDemoteRequest{newCallRequest("box.ctl.demote")}
+}
+
+// Context sets a passed context to the request.
+func (req *CtlDemoteRequest) Context(ctx context.Context) *CtlDemoteRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// ctlWaitArgs returns arguments of box.ctl.wait_rw() and box.ctl.wait_ro().
+// A non-positive timeout means an infinite wait.
+func ctlWaitArgs(timeout time.Duration) []interface{} {
+	if timeout <= 0 {
+		return nil
+	}
+	return []interface{}{timeout.Seconds()}
+}
+
+// CtlWaitRWRequest waits until the instance becomes writable with
+// box.ctl.wait_rw(). The request fails with a timeout error if the
+// instance is still read-only after the timeout.
+type CtlWaitRWRequest struct {
+	callRequest
+}
+
+// NewCtlWaitRWRequest returns a new box.ctl.wait_rw() request. A
+// non-positive timeout means an infinite wait on the instance side, the
+// request could still be limited by a context or by Opts.Timeout of a
+// connection.
+func NewCtlWaitRWRequest(timeout time.Duration) *CtlWaitRWRequest {
+	return &CtlWaitRWRequest{newCallRequest("box.ctl.wait_rw", ctlWaitArgs(timeout)...)}
+}
+
+// Context sets a passed context to the request.
+func (req *CtlWaitRWRequest) Context(ctx context.Context) *CtlWaitRWRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// CtlWaitRORequest waits until the instance becomes read-only with
+// box.ctl.wait_ro(). The request fails with a timeout error if the
+// instance is still writable after the timeout.
+type CtlWaitRORequest struct {
+	callRequest
+}
+
+// NewCtlWaitRORequest returns a new box.ctl.wait_ro() request. A
+// non-positive timeout means an infinite wait on the instance side, the
+// request could still be limited by a context or by Opts.Timeout of a
+// connection.
+func NewCtlWaitRORequest(timeout time.Duration) *CtlWaitRORequest {
+	return &CtlWaitRORequest{newCallRequest("box.ctl.wait_ro", ctlWaitArgs(timeout)...)}
+}
+
+// Context sets a passed context to the request.
+func (req *CtlWaitRORequest) Context(ctx context.Context) *CtlWaitRORequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// CtlMakeBootstrapLeaderRequest makes the instance a bootstrap leader of
+// the replica set with box.ctl.make_bootstrap_leader(). It is supported
+// since Tarantool 3.0 with the "supervised" bootstrap strategy.
+type CtlMakeBootstrapLeaderRequest struct {
+	callRequest
+}
+
+// NewCtlMakeBootstrapLeaderRequest returns a new
+// box.ctl.make_bootstrap_leader() request.
+func NewCtlMakeBootstrapLeaderRequest() *CtlMakeBootstrapLeaderRequest {
+	return &CtlMakeBootstrapLeaderRequest{newCallRequest("box.ctl.make_bootstrap_leader")}
+}
+
+// Context sets a passed context to the request.
+func (req *CtlMakeBootstrapLeaderRequest) Context(
+	ctx context.Context) *CtlMakeBootstrapLeaderRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// Promote makes the instance a leader of the replica set.
+func (b *Box) Promote() error {
+	return do(b.conn, NewCtlPromoteRequest())
+}
+
+// Demote resigns the leadership of the instance.
+func (b *Box) Demote() error {
+	return do(b.conn, NewCtlDemoteRequest())
+}
+
+// WaitRW waits until the instance becomes writable. A non-positive timeout
+// means an infinite wait.
+func (b *Box) WaitRW(timeout time.Duration) error {
+	return do(b.conn, NewCtlWaitRWRequest(timeout))
+}
+
+// WaitRO waits until the instance becomes read-only. A non-positive
+// timeout means an infinite wait.
+func (b *Box) WaitRO(timeout time.Duration) error {
+	return do(b.conn, NewCtlWaitRORequest(timeout))
+}
+
+// MakeBootstrapLeader makes the instance a bootstrap leader of the replica
+// set.
+func (b *Box) MakeBootstrapLeader() error {
+	return do(b.conn, NewCtlMakeBootstrapLeaderRequest())
+}
@@ -0,0 +1,70 @@
+package box_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestBox_Ctl(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+
+	var mutex sync.Mutex
+	var calls []string
+	args := map[string][]interface{}{}
+	record := func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls = append(calls, req.FunctionName())
+		args[req.FunctionName()] = req.Tuple()
+		return nil, nil
+	}
+	for _, function := range []string{
+		"box.ctl.promote",
+		"box.ctl.demote",
+		"box.ctl.wait_rw",
+		"box.ctl.wait_ro",
+		"box.ctl.make_bootstrap_leader",
+	} {
+		server.HandleCall(function, record)
+	}
+
+	b := box.New(conn)
+	require.NoError(t, b.MakeBootstrapLeader())
+	require.NoError(t, b.Promote())
+	require.NoError(t, b.WaitRW(1500*time.Millisecond))
+	require.NoError(t, b.Demote())
+	require.NoError(t, b.WaitRO(0))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{
+		"box.ctl.make_bootstrap_leader",
+		"box.ctl.promote",
+		"box.ctl.wait_rw",
+		"box.ctl.demote",
+		"box.ctl.wait_ro",
+	}, calls)
+	assert.Equal(t, []interface{}{1.5}, args["box.ctl.wait_rw"])
+	assert.Empty(t, args["box.ctl.wait_ro"])
+}
+
+func TestCtlWaitRWRequest_timeout(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	server.HandleCall("box.ctl.wait_rw", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, tarantool.Error{Code: iproto.ER_TIMEOUT, Msg: "timed out"}
+	})
+
+	_, err := conn.Do(box.NewCtlWaitRWRequest(time.Second)).Get()
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_TIMEOUT, tntErr.Code)
+}
@@ -28,6 +28,53 @@ type Info struct {
 	LSN uint64 `msgpack:"lsn"`
 	// Replication - replication status.
 	Replication map[int]Replication `msgpack:"replication,omitempty"`
+	// Election - state of the RAFT-based leader election.
+	Election Election `msgpack:"election,omitempty"`
+	// Synchro - state of the synchronous replication.
+	Synchro Synchro `msgpack:"synchro,omitempty"`
+}
+
+// Election section of box.info() is a state of the RAFT-based leader election.
+type Election struct {
+	// State is a state of the instance: "follower", "candidate" or "leader".
+	State string `msgpack:"state"`
+	// Term is a current election term.
+	Term uint64 `msgpack:"term"`
+	// Vote is an ID of the instance the current instance voted for in the current term.
+	// It is 0 if the instance did not vote.
+	Vote int `msgpack:"vote"`
+	// Leader is an ID of the leader of the current term. It is 0 if the leader is unknown.
+	Leader int `msgpack:"leader"`
+	// LeaderName is a name of the leader. It is empty if the leader has no name.
+	LeaderName string `msgpack:"leader_name,omitempty"`
+	// LeaderIdle is the time (in seconds) since the last message from the leader.
+	LeaderIdle float64 `msgpack:"leader_idle,omitempty"`
+}
+
+// Synchro section of box.info() is a state of the synchronous replication.
+type Synchro struct {
+	// Queue - state of the queue of synchronous transactions.
+	Queue SynchroQueue `msgpack:"queue"`
+	// Quorum is a count of instances required to confirm a synchronous transaction.
+	Quorum int `msgpack:"quorum"`
+}
+
+// SynchroQueue is a state of the queue of synchronous transactions.
+type SynchroQueue struct {
+	// Owner is an ID of the instance that owns the queue. It is 0 if the queue is not owned.
+	Owner int `msgpack:"owner"`
+	// Term is a term of the last PROMOTE request.
+	Term uint64 `msgpack:"term"`
+	// Len is a count of transactions that wait for a quorum.
+	Len int `msgpack:"len"`
+	// Busy is true if the instance is processing or writing a system request
+	// that modifies the queue.
+	Busy bool `msgpack:"busy"`
+	// Age is the time (in seconds) that the oldest transaction waits for a quorum.
+	Age float64 `msgpack:"age,omitempty"`
+	// ConfirmLag is the time (in seconds) that the latest confirmed transaction
+	// waited for a quorum.
+	ConfirmLag float64 `msgpack:"confirm_lag,omitempty"`
 }
 
 // Replication section of box.info() is a table with statistics for all instances
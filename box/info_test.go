@@ -112,6 +112,61 @@ func TestInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Case: info struct with election and synchro",
+			Struct: Info{
+				Version: "3.2.0-0-g0bd5b5bd0d",
+				ID:      &id,
+				RO:      false,
+				UUID:    "69360e9b-4641-4ec3-ab51-297f46749849",
+				PID:     1,
+				Status:  "running",
+				LSN:     8,
+				Election: Election{
+					State:      "leader",
+					Term:       3,
+					Vote:       1,
+					Leader:     1,
+					LeaderName: "instance-001",
+				},
+				Synchro: Synchro{
+					Queue: SynchroQueue{
+						Owner: 1,
+						Term:  3,
+						Len:   2,
+						Busy:  false,
+						Age:   0.5,
+					},
+					Quorum: 2,
+				},
+			},
+			Data: map[string]interface{}{
+				"version": "3.2.0-0-g0bd5b5bd0d",
+				"id":      1,
+				"ro":      false,
+				"uuid":    "69360e9b-4641-4ec3-ab51-297f46749849",
+				"pid":     1,
+				"status":  "running",
+				"lsn":     8,
+				"election": map[string]interface{}{
+					"state":       "leader",
+					"term":        3,
+					"vote":        1,
+					"leader":      1,
+					"leader_name": "instance-001",
+				},
+				"synchro": map[string]interface{}{
+					"queue": map[string]interface{}{
+						"owner": 1,
+						"term":  3,
+						"len":   2,
+						"busy":  false,
+						"age":   0.5,
+					},
+					"quorum": 2,
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		data, err := msgpack.Marshal(tc.Data)
@@ -5,11 +5,13 @@ import (
 	"io"
 
 	"github.com/tarantool/go-iproto"
+	"github.com/vmihailenco/msgpack/v5"
+
 	"github.com/tarantool/go-tarantool/v2"
 )
 
 type baseRequest struct {
-	impl *tarantool.CallRequest
+	impl tarantool.Request
 }
 
 func newCall(method string) *tarantool.CallRequest {
@@ -36,3 +38,59 @@ func (req baseRequest) Response(header tarantool.Header,
 	body io.Reader) (tarantool.Response, error) {
 	return req.impl.Response(header, body)
 }
+
+// evalRequest is a base of requests that execute a Lua chunk.
+type evalRequest struct {
+	baseRequest
+	eval *tarantool.EvalRequest
+}
+
+func newEvalRequest(expr string, args ...interface{}) evalRequest {
+	eval := tarantool.NewEvalRequest(expr).Args(args)
+	return evalRequest{
+		baseRequest: baseRequest{impl: eval},
+		eval:        eval,
+	}
+}
+
+// Body method is used to serialize the request's body.
+// It is part of the tarantool.Request interface implementation.
+func (req evalRequest) Body(res tarantool.SchemaResolver, enc *msgpack.Encoder) error {
+	return req.eval.Body(res, enc)
+}
+
+// callRequest is a base of requests that call a Lua function.
+type callRequest struct {
+	baseRequest
+	call *tarantool.CallRequest
+}
+
+func newCallRequest(function string, args ...interface{}) callRequest {
+	call := newCall(function).Args(args)
+	return callRequest{
+		baseRequest: baseRequest{impl: call},
+		call:        call,
+	}
+}
+
+// withContext returns a copy of the request with the context. The original
+// request is not changed.
+func (req callRequest) withContext(ctx context.Context) callRequest {
+	call := *req.call
+	call.Context(ctx)
+	return callRequest{
+		baseRequest: baseRequest{impl: &call},
+		call:        &call,
+	}
+}
+
+// GetFunction returns the name of the called function.
+func (req callRequest) GetFunction() string {
+	return req.call.GetFunction()
+}
+
+// Body method is used to serialize the request's body.
+// It is part of the tarantool.Request interface implementation.
+func (req callRequest) Body(res tarantool.SchemaResolver, enc *msgpack.Encoder) error {
+	return req.call.Body(res, enc)
+}
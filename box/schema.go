@@ -0,0 +1,467 @@
+package box
+
+import (
+	"context"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// Lua chunks of schema requests. Missing objects are reported with
+// box.error, so a request fails with a tarantool.Error with a code like
+// iproto.ER_NO_SUCH_SPACE.
+const (
+	getSpaceLua = `
+local function get_space(name)
+    local space = box.space[name]
+    if space == nil then
+        box.error(box.error.NO_SUCH_SPACE, tostring(name))
+    end
+    return space
+end
+local function get_index(name, index_name)
+    local index = get_space(name).index[index_name]
+    if index == nil then
+        box.error(box.error.NO_SUCH_INDEX_NAME, tostring(index_name), tostring(name))
+    end
+    return index
+end
+`
+	getSequenceLua = `
+local function get_sequence(name)
+    local sequence = box.sequence[name]
+    if sequence == nil then
+        box.error(box.error.NO_SUCH_SEQUENCE, tostring(name))
+    end
+    return sequence
+end
+`
+
+	spaceCreateLua = `local name, opts = ...
+box.schema.space.create(name, opts)
+return box.space[name].id
+`
+	spaceFormatLua = getSpaceLua + `local name, format = ...
+get_space(name):format(format)
+`
+	spaceRenameLua = getSpaceLua + `local name, new_name = ...
+get_space(name):rename(new_name)
+`
+	spaceDropLua = getSpaceLua + `local name = ...
+get_space(name):drop()
+`
+	indexCreateLua = getSpaceLua + `local name, index_name, opts = ...
+local space = get_space(name)
+space:create_index(index_name, opts)
+return space.index[index_name].id
+`
+	indexAlterLua = getSpaceLua + `local name, index_name, opts = ...
+get_index(name, index_name):alter(opts)
+`
+	indexDropLua = getSpaceLua + `local name, index_name = ...
+get_index(name, index_name):drop()
+`
+	sequenceCreateLua = `local name, opts = ...
+box.schema.sequence.create(name, opts)
+return box.sequence[name].id
+`
+	sequenceAlterLua = getSequenceLua + `local name, opts = ...
+get_sequence(name):alter(opts)
+`
+	sequenceDropLua = getSequenceLua + `local name = ...
+get_sequence(name):drop()
+`
+	sequenceNextLua = getSequenceLua + `local name = ...
+return get_sequence(name):next()
+`
+	sequenceSetLua = getSequenceLua + `local name, value = ...
+get_sequence(name):set(value)
+`
+	sequenceResetLua = getSequenceLua + `local name = ...
+get_sequence(name):reset()
+`
+)
+
+// SpaceField is a field of a space format.
+type SpaceField struct {
+	Name       string `msgpack:"name"`
+	Type       string `msgpack:"type,omitempty"`
+	IsNullable bool   `msgpack:"is_nullable,omitempty"`
+	Collation  string `msgpack:"collation,omitempty"`
+}
+
+// SpaceCreateOpts are options of box.schema.space.create().
+type SpaceCreateOpts struct {
+	// Id is an id of the space. It is generated if not set.
+	Id uint32 `msgpack:"id,omitempty"`
+	// Engine is "memtx" or "vinyl". It is "memtx" by default.
+	Engine string `msgpack:"engine,omitempty"`
+	// FieldCount is a fixed count of fields in tuples.
+	FieldCount uint32 `msgpack:"field_count,omitempty"`
+	// Format is a format of the space.
+	Format []SpaceField `msgpack:"format,omitempty"`
+	// IfNotExists disables an error if the space already exists.
+	IfNotExists bool `msgpack:"if_not_exists,omitempty"`
+	// IsLocal makes the space replication-local.
+	IsLocal bool `msgpack:"is_local,omitempty"`
+	// IsSync makes transactions of the space synchronous.
+	IsSync bool `msgpack:"is_sync,omitempty"`
+	// Temporary makes the space data-temporary.
+	Temporary bool `msgpack:"temporary,omitempty"`
+	// User is a name of an owner of the space.
+	User string `msgpack:"user,omitempty"`
+}
+
+// IndexPart is a part of an index.
+type IndexPart struct {
+	// Field is a name of a field or a field number starting from 1.
+	Field      interface{} `msgpack:"field"`
+	Type       string      `msgpack:"type,omitempty"`
+	IsNullable bool        `msgpack:"is_nullable,omitempty"`
+	Collation  string      `msgpack:"collation,omitempty"`
+	// Path is a JSON path to index a nested field.
+	Path string `msgpack:"path,omitempty"`
+}
+
+// IndexOpts are options of space:create_index() and index:alter().
+type IndexOpts struct {
+	// Id is an id of a new index. It is generated if not set.
+	Id uint32 `msgpack:"id,omitempty"`
+	// Name is a new name of an altered index.
+	Name string `msgpack:"name,omitempty"`
+	// Type is "TREE", "HASH", "BITSET" or "RTREE". It is "TREE" by
+	// default.
+	Type string `msgpack:"type,omitempty"`
+	// Unique is true by default.
+	Unique *bool `msgpack:"unique,omitempty"`
+	// IfNotExists disables an error if the index already exists.
+	IfNotExists bool `msgpack:"if_not_exists,omitempty"`
+	// Parts are parts of the index. It is a first unsigned field by
+	// default.
+	Parts []IndexPart `msgpack:"parts,omitempty"`
+	// Sequence is a name of a sequence or true to create a sequence for
+	// the primary index.
+	Sequence interface{} `msgpack:"sequence,omitempty"`
+	// Dimension is a dimension of a RTREE index.
+	Dimension uint32 `msgpack:"dimension,omitempty"`
+	// Distance is "euclid" or "manhattan" for a RTREE index.
+	Distance string `msgpack:"distance,omitempty"`
+}
+
+// SequenceOpts are options of box.schema.sequence.create() and
+// sequence:alter().
+type SequenceOpts struct {
+	Start *int64 `msgpack:"start,omitempty"`
+	Min   *int64 `msgpack:"min,omitempty"`
+	Max   *int64 `msgpack:"max,omitempty"`
+	// Step is 1 by default.
+	Step  int64 `msgpack:"step,omitempty"`
+	Cycle bool  `msgpack:"cycle,omitempty"`
+	// Cache is a count of values to cache. It is not used by Tarantool yet.
+	Cache uint64 `msgpack:"cache,omitempty"`
+	// IfNotExists disables an error if the sequence already exists.
+	IfNotExists bool `msgpack:"if_not_exists,omitempty"`
+}
+
+// SpaceCreateRequest creates a space with box.schema.space.create(). The
+// response data contains an id of the space.
+type SpaceCreateRequest struct {
+	evalRequest
+}
+
+// NewSpaceCreateRequest returns a new request to create a space.
+func NewSpaceCreateRequest(name string, opts SpaceCreateOpts) *SpaceCreateRequest {
+	return &SpaceCreateRequest{newEvalRequest(spaceCreateLua, name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *SpaceCreateRequest) Context(ctx context.Context) *SpaceCreateRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SpaceFormatRequest sets a format of a space with space:format().
+type SpaceFormatRequest struct {
+	evalRequest
+}
+
+// NewSpaceFormatRequest returns a new request to set a format of a space.
+func NewSpaceFormatRequest(space string, format []SpaceField) *SpaceFormatRequest {
+	if format == nil {
+		format = []SpaceField{}
+	}
+	return &SpaceFormatRequest{newEvalRequest(spaceFormatLua, space, format)}
+}
+
+// Context sets a passed context to the request.
+func (req *SpaceFormatRequest) Context(ctx context.Context) *SpaceFormatRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SpaceRenameRequest renames a space with space:rename().
+type SpaceRenameRequest struct {
+	evalRequest
+}
+
+// NewSpaceRenameRequest returns a new request to rename a space.
+func NewSpaceRenameRequest(space, newName string) *SpaceRenameRequest {
+	return &SpaceRenameRequest{newEvalRequest(spaceRenameLua, space, newName)}
+}
+
+// Context sets a passed context to the request.
+func (req *SpaceRenameRequest) Context(ctx context.Context) *SpaceRenameRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SpaceDropRequest drops a space with space:drop().
+type SpaceDropRequest struct {
+	evalRequest
+}
+
+// NewSpaceDropRequest returns a new request to drop a space.
+func NewSpaceDropRequest(space string) *SpaceDropRequest {
+	return &SpaceDropRequest{newEvalRequest(spaceDropLua, space)}
+}
+
+// Context sets a passed context to the request.
+func (req *SpaceDropRequest) Context(ctx context.Context) *SpaceDropRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// IndexCreateRequest creates an index with space:create_index(). The
+// response data contains an id of the index.
+type IndexCreateRequest struct {
+	evalRequest
+}
+
+// NewIndexCreateRequest returns a new request to create an index.
+func NewIndexCreateRequest(space, index string, opts IndexOpts) *IndexCreateRequest {
+	return &IndexCreateRequest{newEvalRequest(indexCreateLua, space, index, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *IndexCreateRequest) Context(ctx context.Context) *IndexCreateRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// IndexAlterRequest changes an index with index:alter(). Only options
+// that are set are changed.
+type IndexAlterRequest struct {
+	evalRequest
+}
+
+// NewIndexAlterRequest returns a new request to alter an index.
+func NewIndexAlterRequest(space, index string, opts IndexOpts) *IndexAlterRequest {
+	return &IndexAlterRequest{newEvalRequest(indexAlterLua, space, index, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *IndexAlterRequest) Context(ctx context.Context) *IndexAlterRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// IndexDropRequest drops an index with index:drop().
+type IndexDropRequest struct {
+	evalRequest
+}
+
+// NewIndexDropRequest returns a new request to drop an index.
+func NewIndexDropRequest(space, index string) *IndexDropRequest {
+	return &IndexDropRequest{newEvalRequest(indexDropLua, space, index)}
+}
+
+// Context sets a passed context to the request.
+func (req *IndexDropRequest) Context(ctx context.Context) *IndexDropRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SequenceCreateRequest creates a sequence with box.schema.sequence.create().
+// The response data contains an id of the sequence.
+type SequenceCreateRequest struct {
+	evalRequest
+}
+
+// NewSequenceCreateRequest returns a new request to create a sequence.
+func NewSequenceCreateRequest(name string, opts SequenceOpts) *SequenceCreateRequest {
+	return &SequenceCreateRequest{newEvalRequest(sequenceCreateLua, name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *SequenceCreateRequest) Context(ctx context.Context) *SequenceCreateRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SequenceAlterRequest changes a sequence with sequence:alter().
+type SequenceAlterRequest struct {
+	evalRequest
+}
+
+// NewSequenceAlterRequest returns a new request to alter a sequence.
+// IfNotExists option is not used.
+func NewSequenceAlterRequest(name string, opts SequenceOpts) *SequenceAlterRequest {
+	opts.IfNotExists = false
+	return &SequenceAlterRequest{newEvalRequest(sequenceAlterLua, name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *SequenceAlterRequest) Context(ctx context.Context) *SequenceAlterRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SequenceDropRequest drops a sequence with sequence:drop().
+type SequenceDropRequest struct {
+	evalRequest
+}
+
+// NewSequenceDropRequest returns a new request to drop a sequence.
+func NewSequenceDropRequest(name string) *SequenceDropRequest {
+	return &SequenceDropRequest{newEvalRequest(sequenceDropLua, name)}
+}
+
+// Context sets a passed context to the request.
+func (req *SequenceDropRequest) Context(ctx context.Context) *SequenceDropRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SequenceNextRequest generates the next value of a sequence with
+// sequence:next(). The response data contains the value.
+type SequenceNextRequest struct {
+	evalRequest
+}
+
+// NewSequenceNextRequest returns a new request to get the next value of a
+// sequence.
+func NewSequenceNextRequest(name string) *SequenceNextRequest {
+	return &SequenceNextRequest{newEvalRequest(sequenceNextLua, name)}
+}
+
+// Context sets a passed context to the request.
+func (req *SequenceNextRequest) Context(ctx context.Context) *SequenceNextRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SequenceSetRequest sets the current value of a sequence with
+// sequence:set().
+type SequenceSetRequest struct {
+	evalRequest
+}
+
+// NewSequenceSetRequest returns a new request to set the current value of a
+// sequence.
+func NewSequenceSetRequest(name string, value int64) *SequenceSetRequest {
+	return &SequenceSetRequest{newEvalRequest(sequenceSetLua, name, value)}
+}
+
+// Context sets a passed context to the request.
+func (req *SequenceSetRequest) Context(ctx context.Context) *SequenceSetRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// SequenceResetRequest resets a sequence to the initial state with
+// sequence:reset().
+type SequenceResetRequest struct {
+	evalRequest
+}
+
+// NewSequenceResetRequest returns a new request to reset a sequence.
+func NewSequenceResetRequest(name string) *SequenceResetRequest {
+	return &SequenceResetRequest{newEvalRequest(sequenceResetLua, name)}
+}
+
+// Context sets a passed context to the request.
+func (req *SequenceResetRequest) Context(ctx context.Context) *SequenceResetRequest {
+	req.eval.Context(ctx)
+	return req
+}
+
+// doId performs a request and decodes an id from a response.
+func doId(doer tarantool.Doer, req tarantool.Request) (uint32, error) {
+	var id uint32
+	if err := doer.Do(req).GetTyped(&[]interface{}{&id}); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// do performs a request without a result.
+func do(doer tarantool.Doer, req tarantool.Request) error {
+	_, err := doer.Do(req).Get()
+	return err
+}
+
+// SpaceCreate creates a space and returns its id.
+func (b *Box) SpaceCreate(name string, opts SpaceCreateOpts) (uint32, error) {
+	return doId(b.conn, NewSpaceCreateRequest(name, opts))
+}
+
+// SpaceFormat sets a format of a space.
+func (b *Box) SpaceFormat(space string, format []SpaceField) error {
+	return do(b.conn, NewSpaceFormatRequest(space, format))
+}
+
+// SpaceRename renames a space.
+func (b *Box) SpaceRename(space, newName string) error {
+	return do(b.conn, NewSpaceRenameRequest(space, newName))
+}
+
+// SpaceDrop drops a space.
+func (b *Box) SpaceDrop(space string) error {
+	return do(b.conn, NewSpaceDropRequest(space))
+}
+
+// IndexCreate creates an index and returns its id.
+func (b *Box) IndexCreate(space, index string, opts IndexOpts) (uint32, error) {
+	return doId(b.conn, NewIndexCreateRequest(space, index, opts))
+}
+
+// IndexAlter changes an index.
+func (b *Box) IndexAlter(space, index string, opts IndexOpts) error {
+	return do(b.conn, NewIndexAlterRequest(space, index, opts))
+}
+
+// IndexDrop drops an index.
+func (b *Box) IndexDrop(space, index string) error {
+	return do(b.conn, NewIndexDropRequest(space, index))
+}
+
+// SequenceCreate creates a sequence and returns its id.
+func (b *Box) SequenceCreate(name string, opts SequenceOpts) (uint32, error) {
+	return doId(b.conn, NewSequenceCreateRequest(name, opts))
+}
+
+// SequenceAlter changes a sequence.
+func (b *Box) SequenceAlter(name string, opts SequenceOpts) error {
+	return do(b.conn, NewSequenceAlterRequest(name, opts))
+}
+
+// SequenceDrop drops a sequence.
+func (b *Box) SequenceDrop(name string) error {
+	return do(b.conn, NewSequenceDropRequest(name))
+}
+
+// SequenceNext generates the next value of a sequence.
+func (b *Box) SequenceNext(name string) (int64, error) {
+	var value int64
+	err := b.conn.Do(NewSequenceNextRequest(name)).GetTyped(&[]interface{}{&value})
+	return value, err
+}
+
+// SequenceSet sets the current value of a sequence.
+func (b *Box) SequenceSet(name string, value int64) error {
+	return do(b.conn, NewSequenceSetRequest(name, value))
+}
+
+// SequenceReset resets a sequence to the initial state.
+func (b *Box) SequenceReset(name string) error {
+	return do(b.conn, NewSequenceResetRequest(name))
+}
@@ -0,0 +1,189 @@
+package box_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+// fakeSchema handles schema requests with an in-memory list of spaces and
+// sequences.
+type fakeSchema struct {
+	mutex     sync.Mutex
+	spaces    map[string]uint32
+	sequences map[string]int64
+	args      []interface{}
+}
+
+func (s *fakeSchema) handle(req *mockserver.Request) ([]interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expr, args := req.Expression(), req.Tuple()
+	s.args = args
+	name, _ := args[0].(string)
+	switch {
+	case strings.Contains(expr, "box.schema.space.create"):
+		if _, ok := s.spaces[name]; ok {
+			return nil, tarantool.Error{
+				Code: iproto.ER_SPACE_EXISTS,
+				Msg:  "Space '" + name + "' already exists",
+			}
+		}
+		s.spaces[name] = uint32(512 + len(s.spaces))
+		return []interface{}{s.spaces[name]}, nil
+	case strings.Contains(expr, "box.schema.sequence.create"):
+		s.sequences[name] = 0
+		return []interface{}{1}, nil
+	case strings.Contains(expr, "get_sequence(name):next()"):
+		value, ok := s.sequences[name]
+		if !ok {
+			return nil, tarantool.Error{
+				Code: iproto.ER_NO_SUCH_SEQUENCE,
+				Msg:  "Sequence '" + name + "' does not exist",
+			}
+		}
+		s.sequences[name] = value + 1
+		return []interface{}{value + 1}, nil
+	case strings.Contains(expr, "get_space(name)"):
+		if _, ok := s.spaces[name]; !ok {
+			return nil, tarantool.Error{
+				Code: iproto.ER_NO_SUCH_SPACE,
+				Msg:  "Space '" + name + "' does not exist",
+			}
+		}
+		if strings.Contains(expr, "create_index") {
+			return []interface{}{0}, nil
+		}
+		return nil, nil
+	}
+	return nil, errors.New("unexpected expression")
+}
+
+func (s *fakeSchema) lastArgs() []interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.args
+}
+
+// handleFakeSchema sets a handler of schema requests of the server.
+func handleFakeSchema(server *mockserver.Server) *fakeSchema {
+	schema := &fakeSchema{
+		spaces:    make(map[string]uint32),
+		sequences: make(map[string]int64),
+	}
+	server.Handle(iproto.IPROTO_EVAL, schema.handle)
+	return schema
+}
+
+func TestBox_SpaceCreate(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	schema := handleFakeSchema(server)
+	b := box.New(conn)
+
+	id, err := b.SpaceCreate("users", box.SpaceCreateOpts{
+		Engine: "vinyl",
+		Format: []box.SpaceField{
+			{Name: "id", Type: "unsigned"},
+			{Name: "email", Type: "string", IsNullable: true},
+		},
+		IfNotExists: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(512), id)
+
+	args := schema.lastArgs()
+	require.Len(t, args, 2)
+	assert.Equal(t, "users", args[0])
+	assert.Equal(t, map[string]interface{}{
+		"engine": "vinyl",
+		"format": []interface{}{
+			map[string]interface{}{"name": "id", "type": "unsigned"},
+			map[string]interface{}{"name": "email", "type": "string", "is_nullable": true},
+		},
+		"if_not_exists": true,
+	}, args[1])
+
+	_, err = b.SpaceCreate("users", box.SpaceCreateOpts{})
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_SPACE_EXISTS, tntErr.Code)
+}
+
+func TestBox_SpaceRequests_noSuchSpace(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	handleFakeSchema(server)
+	b := box.New(conn)
+
+	errs := map[string]error{
+		"SpaceFormat": b.SpaceFormat("users", nil),
+		"SpaceRename": b.SpaceRename("users", "clients"),
+		"SpaceDrop":   b.SpaceDrop("users"),
+		"IndexAlter":  b.IndexAlter("users", "primary", box.IndexOpts{}),
+		"IndexDrop":   b.IndexDrop("users", "primary"),
+	}
+	_, errs["IndexCreate"] = b.IndexCreate("users", "primary", box.IndexOpts{})
+	for name, err := range errs {
+		var tntErr tarantool.Error
+		if assert.ErrorAs(t, err, &tntErr, name) {
+			assert.Equal(t, iproto.ER_NO_SUCH_SPACE, tntErr.Code, name)
+		}
+	}
+}
+
+func TestIndexCreateRequest(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	schema := handleFakeSchema(server)
+
+	_, err := conn.Do(box.NewSpaceCreateRequest("users", box.SpaceCreateOpts{})).Get()
+	require.NoError(t, err)
+
+	unique := false
+	req := box.NewIndexCreateRequest("users", "name", box.IndexOpts{
+		Type:   "TREE",
+		Unique: &unique,
+		Parts:  []box.IndexPart{{Field: "name", Type: "string", Collation: "unicode_ci"}},
+	}).Context(context.Background())
+	var id uint32
+	require.NoError(t, conn.Do(req).GetTyped(&[]interface{}{&id}))
+	assert.Equal(t, uint32(0), id)
+
+	assert.Equal(t, []interface{}{"users", "name", map[string]interface{}{
+		"type":   "TREE",
+		"unique": false,
+		"parts": []interface{}{
+			map[string]interface{}{"field": "name", "type": "string", "collation": "unicode_ci"},
+		},
+	}}, schema.lastArgs())
+}
+
+func TestBox_Sequence(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	handleFakeSchema(server)
+	b := box.New(conn)
+
+	_, err := b.SequenceNext("id")
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_NO_SUCH_SEQUENCE, tntErr.Code)
+
+	start := int64(1)
+	_, err = b.SequenceCreate("id", box.SequenceOpts{Start: &start})
+	require.NoError(t, err)
+
+	for _, expected := range []int64{1, 2} {
+		value, err := b.SequenceNext("id")
+		require.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+}
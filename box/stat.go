@@ -0,0 +1,399 @@
+package box
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+var (
+	_ tarantool.Request = StatRequest{}
+	_ tarantool.Request = StatNetRequest{}
+	_ tarantool.Request = SlabInfoRequest{}
+	_ tarantool.Request = SlabStatsRequest{}
+	_ tarantool.Request = RuntimeInfoRequest{}
+	_ tarantool.Request = InfoMemoryRequest{}
+	_ tarantool.Request = InfoGCRequest{}
+)
+
+// decodeSingle decodes response data that contains a single value.
+func decodeSingle(d *msgpack.Decoder, v interface{}) error {
+	arrayLen, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if arrayLen != 1 {
+		return fmt.Errorf("protocol violation; expected 1 array entry, got %d", arrayLen)
+	}
+	return d.Decode(v)
+}
+
+// StatCounter is a counter of box.stat().
+type StatCounter struct {
+	// Total is a total count since the instance start.
+	Total uint64 `msgpack:"total"`
+	// RPS is an average count per second for the last 5 seconds.
+	RPS uint64 `msgpack:"rps"`
+}
+
+// Stat contains counters of requests of box.stat().
+type Stat struct {
+	Delete   StatCounter `msgpack:"DELETE"`
+	Select   StatCounter `msgpack:"SELECT"`
+	Insert   StatCounter `msgpack:"INSERT"`
+	Eval     StatCounter `msgpack:"EVAL"`
+	Call     StatCounter `msgpack:"CALL"`
+	Replace  StatCounter `msgpack:"REPLACE"`
+	Upsert   StatCounter `msgpack:"UPSERT"`
+	Auth     StatCounter `msgpack:"AUTH"`
+	Execute  StatCounter `msgpack:"EXECUTE"`
+	Update   StatCounter `msgpack:"UPDATE"`
+	Begin    StatCounter `msgpack:"BEGIN"`
+	Commit   StatCounter `msgpack:"COMMIT"`
+	Rollback StatCounter `msgpack:"ROLLBACK"`
+	Prepare  StatCounter `msgpack:"PREPARE"`
+	// Error is a counter of errors.
+	Error StatCounter `msgpack:"ERROR"`
+}
+
+// StatResponse is a response to StatRequest.
+type StatResponse struct {
+	Stat Stat
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *StatResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	return decodeSingle(d, &r.Stat)
+}
+
+// StatRequest is a request to get box.stat() counters.
+type StatRequest struct {
+	callRequest
+}
+
+// NewStatRequest returns a new box.stat() request.
+func NewStatRequest() StatRequest {
+	return StatRequest{newCallRequest("box.stat")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req StatRequest) Context(ctx context.Context) StatRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// StatNetCounter is a counter of box.stat.net().
+type StatNetCounter struct {
+	// Total is a total count since the instance start.
+	Total uint64 `msgpack:"total"`
+	// RPS is an average count per second for the last 5 seconds.
+	RPS uint64 `msgpack:"rps"`
+	// Current is a current count. It is not set for the byte counters.
+	Current uint64 `msgpack:"current,omitempty"`
+}
+
+// StatNet contains network counters of box.stat.net().
+type StatNet struct {
+	// Sent is a count of sent bytes.
+	Sent StatNetCounter `msgpack:"SENT"`
+	// Received is a count of received bytes.
+	Received              StatNetCounter `msgpack:"RECEIVED"`
+	Connections           StatNetCounter `msgpack:"CONNECTIONS"`
+	Requests              StatNetCounter `msgpack:"REQUESTS"`
+	RequestsInProgress    StatNetCounter `msgpack:"REQUESTS_IN_PROGRESS"`
+	RequestsInStreamQueue StatNetCounter `msgpack:"REQUESTS_IN_STREAM_QUEUE"`
+	Streams               StatNetCounter `msgpack:"STREAMS"`
+}
+
+// StatNetResponse is a response to StatNetRequest.
+type StatNetResponse struct {
+	StatNet StatNet
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *StatNetResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	return decodeSingle(d, &r.StatNet)
+}
+
+// StatNetRequest is a request to get box.stat.net() counters.
+type StatNetRequest struct {
+	callRequest
+}
+
+// NewStatNetRequest returns a new box.stat.net() request.
+func NewStatNetRequest() StatNetRequest {
+	return StatNetRequest{newCallRequest("box.stat.net")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req StatNetRequest) Context(ctx context.Context) StatNetRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// SlabInfo contains memory usage of the memtx slab allocator from
+// box.slab.info(). Ratios are strings like "12.5%".
+type SlabInfo struct {
+	ItemsSize      uint64 `msgpack:"items_size"`
+	ItemsUsed      uint64 `msgpack:"items_used"`
+	ItemsUsedRatio string `msgpack:"items_used_ratio"`
+	QuotaSize      uint64 `msgpack:"quota_size"`
+	QuotaUsed      uint64 `msgpack:"quota_used"`
+	QuotaUsedRatio string `msgpack:"quota_used_ratio"`
+	ArenaSize      uint64 `msgpack:"arena_size"`
+	ArenaUsed      uint64 `msgpack:"arena_used"`
+	ArenaUsedRatio string `msgpack:"arena_used_ratio"`
+}
+
+// SlabInfoResponse is a response to SlabInfoRequest.
+type SlabInfoResponse struct {
+	SlabInfo SlabInfo
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *SlabInfoResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	return decodeSingle(d, &r.SlabInfo)
+}
+
+// SlabInfoRequest is a request to get box.slab.info().
+type SlabInfoRequest struct {
+	callRequest
+}
+
+// NewSlabInfoRequest returns a new box.slab.info() request.
+func NewSlabInfoRequest() SlabInfoRequest {
+	return SlabInfoRequest{newCallRequest("box.slab.info")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req SlabInfoRequest) Context(ctx context.Context) SlabInfoRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// SlabStat contains memory usage of slabs of an item size from
+// box.slab.stats().
+type SlabStat struct {
+	ItemSize  uint64 `msgpack:"item_size"`
+	ItemCount uint64 `msgpack:"item_count"`
+	SlabSize  uint64 `msgpack:"slab_size"`
+	SlabCount uint64 `msgpack:"slab_count"`
+	MemUsed   uint64 `msgpack:"mem_used"`
+	MemFree   uint64 `msgpack:"mem_free"`
+}
+
+// SlabStatsResponse is a response to SlabStatsRequest.
+type SlabStatsResponse struct {
+	SlabStats []SlabStat
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *SlabStatsResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	r.SlabStats = nil
+	return decodeSingle(d, &r.SlabStats)
+}
+
+// SlabStatsRequest is a request to get box.slab.stats().
+type SlabStatsRequest struct {
+	callRequest
+}
+
+// NewSlabStatsRequest returns a new box.slab.stats() request.
+func NewSlabStatsRequest() SlabStatsRequest {
+	return SlabStatsRequest{newCallRequest("box.slab.stats")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req SlabStatsRequest) Context(ctx context.Context) SlabStatsRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// RuntimeInfo contains memory usage of the Lua runtime from
+// box.runtime.info().
+type RuntimeInfo struct {
+	// Lua is a size of the Lua heap.
+	Lua uint64 `msgpack:"lua"`
+	// Used is a size of the runtime arena in use.
+	Used uint64 `msgpack:"used"`
+	// MaxAlloc is a maximum size of the runtime arena.
+	MaxAlloc uint64 `msgpack:"maxalloc"`
+}
+
+// RuntimeInfoResponse is a response to RuntimeInfoRequest.
+type RuntimeInfoResponse struct {
+	RuntimeInfo RuntimeInfo
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *RuntimeInfoResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	return decodeSingle(d, &r.RuntimeInfo)
+}
+
+// RuntimeInfoRequest is a request to get box.runtime.info().
+type RuntimeInfoRequest struct {
+	callRequest
+}
+
+// NewRuntimeInfoRequest returns a new box.runtime.info() request.
+func NewRuntimeInfoRequest() RuntimeInfoRequest {
+	return RuntimeInfoRequest{newCallRequest("box.runtime.info")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req RuntimeInfoRequest) Context(ctx context.Context) RuntimeInfoRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// InfoMemory contains memory usage of the instance from box.info.memory().
+type InfoMemory struct {
+	// Cache is a size of the vinyl cache.
+	Cache uint64 `msgpack:"cache"`
+	// Data is a size of tuples.
+	Data uint64 `msgpack:"data"`
+	// Index is a size of indexes.
+	Index uint64 `msgpack:"index"`
+	// Lua is a size of the Lua heap.
+	Lua uint64 `msgpack:"lua"`
+	// Net is a size of network buffers.
+	Net uint64 `msgpack:"net"`
+	// Tx is a size of transaction data.
+	Tx uint64 `msgpack:"tx"`
+}
+
+// InfoMemoryResponse is a response to InfoMemoryRequest.
+type InfoMemoryResponse struct {
+	InfoMemory InfoMemory
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *InfoMemoryResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	return decodeSingle(d, &r.InfoMemory)
+}
+
+// InfoMemoryRequest is a request to get box.info.memory().
+type InfoMemoryRequest struct {
+	callRequest
+}
+
+// NewInfoMemoryRequest returns a new box.info.memory() request.
+func NewInfoMemoryRequest() InfoMemoryRequest {
+	return InfoMemoryRequest{newCallRequest("box.info.memory")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req InfoMemoryRequest) Context(ctx context.Context) InfoMemoryRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// GCCheckpoint is a checkpoint of box.info.gc().
+type GCCheckpoint struct {
+	// References are names of consumers that use the checkpoint, for
+	// example, "backup".
+	References []string       `msgpack:"references"`
+	VClock     map[int]uint64 `msgpack:"vclock"`
+	Signature  int64          `msgpack:"signature"`
+}
+
+// GCConsumer is a consumer of box.info.gc() that prevents WAL files from
+// being removed.
+type GCConsumer struct {
+	Name      string         `msgpack:"name"`
+	VClock    map[int]uint64 `msgpack:"vclock"`
+	Signature int64          `msgpack:"signature"`
+}
+
+// InfoGC contains a state of the garbage collector of checkpoints and WAL
+// files from box.info.gc().
+type InfoGC struct {
+	// VClock is a vclock of the oldest WAL file.
+	VClock map[int]uint64 `msgpack:"vclock"`
+	// Signature is a sum of the vclock.
+	Signature int64 `msgpack:"signature"`
+	// CheckpointIsInProgress is true if a checkpoint is being made.
+	CheckpointIsInProgress bool           `msgpack:"checkpoint_is_in_progress"`
+	Checkpoints            []GCCheckpoint `msgpack:"checkpoints"`
+	Consumers              []GCConsumer   `msgpack:"consumers"`
+}
+
+// InfoGCResponse is a response to InfoGCRequest.
+type InfoGCResponse struct {
+	InfoGC InfoGC
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *InfoGCResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	r.InfoGC = InfoGC{}
+	return decodeSingle(d, &r.InfoGC)
+}
+
+// InfoGCRequest is a request to get box.info.gc().
+type InfoGCRequest struct {
+	callRequest
+}
+
+// NewInfoGCRequest returns a new box.info.gc() request.
+func NewInfoGCRequest() InfoGCRequest {
+	return InfoGCRequest{newCallRequest("box.info.gc")}
+}
+
+// Context returns a copy of the request with the passed context.
+func (req InfoGCRequest) Context(ctx context.Context) InfoGCRequest {
+	req.callRequest = req.withContext(ctx)
+	return req
+}
+
+// Stat returns counters of requests of the instance.
+func (b *Box) Stat() (Stat, error) {
+	var resp StatResponse
+	err := b.conn.Do(NewStatRequest()).GetTyped(&resp)
+	return resp.Stat, err
+}
+
+// StatNet returns network counters of the instance.
+func (b *Box) StatNet() (StatNet, error) {
+	var resp StatNetResponse
+	err := b.conn.Do(NewStatNetRequest()).GetTyped(&resp)
+	return resp.StatNet, err
+}
+
+// SlabInfo returns memory usage of the memtx slab allocator.
+func (b *Box) SlabInfo() (SlabInfo, error) {
+	var resp SlabInfoResponse
+	err := b.conn.Do(NewSlabInfoRequest()).GetTyped(&resp)
+	return resp.SlabInfo, err
+}
+
+// SlabStats returns memory usage of slabs per item size.
+func (b *Box) SlabStats() ([]SlabStat, error) {
+	var resp SlabStatsResponse
+	err := b.conn.Do(NewSlabStatsRequest()).GetTyped(&resp)
+	return resp.SlabStats, err
+}
+
+// RuntimeInfo returns memory usage of the Lua runtime.
+func (b *Box) RuntimeInfo() (RuntimeInfo, error) {
+	var resp RuntimeInfoResponse
+	err := b.conn.Do(NewRuntimeInfoRequest()).GetTyped(&resp)
+	return resp.RuntimeInfo, err
+}
+
+// InfoMemory returns memory usage of the instance.
+func (b *Box) InfoMemory() (InfoMemory, error) {
+	var resp InfoMemoryResponse
+	err := b.conn.Do(NewInfoMemoryRequest()).GetTyped(&resp)
+	return resp.InfoMemory, err
+}
+
+// InfoGC returns a state of the garbage collector of checkpoints and WAL
+// files.
+func (b *Box) InfoGC() (InfoGC, error) {
+	var resp InfoGCResponse
+	err := b.conn.Do(NewInfoGCRequest()).GetTyped(&resp)
+	return resp.InfoGC, err
+}
@@ -0,0 +1,200 @@
+package box_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func handleResult(server *mockserver.Server, function string, result interface{}) {
+	server.HandleCall(function, func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{result}, nil
+	})
+}
+
+func TestBox_Stat(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	handleResult(server, "box.stat", map[string]interface{}{
+		"SELECT": map[string]interface{}{"total": 120, "rps": 3},
+		"ERROR":  map[string]interface{}{"total": 2, "rps": 0},
+		"AUTH":   map[string]interface{}{"total": 5, "rps": 1},
+		"PUSH":   map[string]interface{}{"total": 1, "rps": 0},
+	})
+	handleResult(server, "box.stat.net", map[string]interface{}{
+		"SENT":        map[string]interface{}{"total": 4096, "rps": 100},
+		"CONNECTIONS": map[string]interface{}{"total": 10, "rps": 0, "current": 2},
+	})
+
+	b := box.New(conn)
+	stat, err := b.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, box.Stat{
+		Select: box.StatCounter{Total: 120, RPS: 3},
+		Error:  box.StatCounter{Total: 2},
+		Auth:   box.StatCounter{Total: 5, RPS: 1},
+	}, stat)
+
+	statNet, err := b.StatNet()
+	require.NoError(t, err)
+	assert.Equal(t, box.StatNet{
+		Sent:        box.StatNetCounter{Total: 4096, RPS: 100},
+		Connections: box.StatNetCounter{Total: 10, Current: 2},
+	}, statNet)
+}
+
+func TestBox_Slab(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	handleResult(server, "box.slab.info", map[string]interface{}{
+		"items_size":       228128,
+		"items_used_ratio": "1.8%",
+		"quota_size":       1073741824,
+		"quota_used_ratio": "0.8%",
+		"arena_used_ratio": "43.2%",
+		"items_used":       4208,
+		"quota_used":       8388608,
+		"arena_size":       2325176,
+		"arena_used":       1003632,
+	})
+	handleResult(server, "box.slab.stats", []interface{}{
+		map[string]interface{}{
+			"mem_free":   9280,
+			"mem_used":   6976,
+			"item_count": 109,
+			"item_size":  64,
+			"slab_count": 1,
+			"slab_size":  16384,
+		},
+	})
+
+	b := box.New(conn)
+	info, err := b.SlabInfo()
+	require.NoError(t, err)
+	assert.Equal(t, box.SlabInfo{
+		ItemsSize:      228128,
+		ItemsUsed:      4208,
+		ItemsUsedRatio: "1.8%",
+		QuotaSize:      1073741824,
+		QuotaUsed:      8388608,
+		QuotaUsedRatio: "0.8%",
+		ArenaSize:      2325176,
+		ArenaUsed:      1003632,
+		ArenaUsedRatio: "43.2%",
+	}, info)
+
+	stats, err := b.SlabStats()
+	require.NoError(t, err)
+	assert.Equal(t, []box.SlabStat{{
+		ItemSize:  64,
+		ItemCount: 109,
+		SlabSize:  16384,
+		SlabCount: 1,
+		MemUsed:   6976,
+		MemFree:   9280,
+	}}, stats)
+}
+
+func TestBox_RuntimeInfoAndMemory(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	handleResult(server, "box.runtime.info", map[string]interface{}{
+		"lua":      2452775,
+		"maxalloc": 4398046510080,
+		"used":     25165824,
+	})
+	handleResult(server, "box.info.memory", map[string]interface{}{
+		"cache": 0,
+		"data":  6552,
+		"tx":    0,
+		"lua":   2088448,
+		"net":   98304,
+		"index": 1196032,
+	})
+
+	b := box.New(conn)
+	runtime, err := b.RuntimeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, box.RuntimeInfo{
+		Lua:      2452775,
+		Used:     25165824,
+		MaxAlloc: 4398046510080,
+	}, runtime)
+
+	memory, err := b.InfoMemory()
+	require.NoError(t, err)
+	assert.Equal(t, box.InfoMemory{
+		Data:  6552,
+		Index: 1196032,
+		Lua:   2088448,
+		Net:   98304,
+	}, memory)
+}
+
+func TestBox_InfoGC(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	handleResult(server, "box.info.gc", map[string]interface{}{
+		"vclock":                    map[int]int{1: 8},
+		"signature":                 8,
+		"checkpoint_is_in_progress": false,
+		"checkpoints": []interface{}{
+			map[string]interface{}{
+				"references": []string{"backup"},
+				"vclock":     map[int]int{1: 8},
+				"signature":  8,
+			},
+		},
+		"consumers": []interface{}{
+			map[string]interface{}{
+				"name":      "replica 9b5f7cb2-9c1f-4b5f-a9a4-2f3a3b6d5a71",
+				"vclock":    map[int]int{1: 10},
+				"signature": 10,
+			},
+		},
+	})
+
+	gc, err := box.New(conn).InfoGC()
+	require.NoError(t, err)
+	assert.Equal(t, box.InfoGC{
+		VClock:    map[int]uint64{1: 8},
+		Signature: 8,
+		Checkpoints: []box.GCCheckpoint{{
+			References: []string{"backup"},
+			VClock:     map[int]uint64{1: 8},
+			Signature:  8,
+		}},
+		Consumers: []box.GCConsumer{{
+			Name:      "replica 9b5f7cb2-9c1f-4b5f-a9a4-2f3a3b6d5a71",
+			VClock:    map[int]uint64{1: 10},
+			Signature: 10,
+		}},
+	}, gc)
+}
+
+func TestStatRequests_context(t *testing.T) {
+	_, conn := mockserver.Connect(t, tarantool.Opts{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, req := range []tarantool.Request{
+		box.NewStatRequest().Context(ctx),
+		box.NewStatNetRequest().Context(ctx),
+		box.NewSlabInfoRequest().Context(ctx),
+		box.NewSlabStatsRequest().Context(ctx),
+		box.NewRuntimeInfoRequest().Context(ctx),
+		box.NewInfoMemoryRequest().Context(ctx),
+		box.NewInfoGCRequest().Context(ctx),
+	} {
+		_, err := conn.Do(req).Get()
+		assert.ErrorContains(t, err, "context is done")
+	}
+
+	// Requests are values, Context() does not change the original one.
+	req := box.NewStatRequest()
+	withCtx := req.Context(ctx)
+	assert.Nil(t, req.Ctx())
+	assert.Equal(t, ctx, withCtx.Ctx())
+}
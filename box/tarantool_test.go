@@ -67,6 +67,43 @@ func TestBox_Info(t *testing.T) {
 	validateInfo(t, resp.Info)
 }
 
+func TestBox_Sugar_Stats(t *testing.T) {
+	ctx := context.TODO()
+
+	conn, err := tarantool.Connect(ctx, dialer, tarantool.Opts{})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	b := box.New(conn)
+
+	stat, err := b.Stat()
+	require.NoError(t, err)
+	require.NotZero(t, stat.Auth.Total)
+
+	statNet, err := b.StatNet()
+	require.NoError(t, err)
+	require.NotZero(t, statNet.Connections.Current)
+
+	slabInfo, err := b.SlabInfo()
+	require.NoError(t, err)
+	require.NotZero(t, slabInfo.QuotaSize)
+
+	_, err = b.SlabStats()
+	require.NoError(t, err)
+
+	runtimeInfo, err := b.RuntimeInfo()
+	require.NoError(t, err)
+	require.NotZero(t, runtimeInfo.Lua)
+
+	memory, err := b.InfoMemory()
+	require.NoError(t, err)
+	require.NotZero(t, memory.Lua)
+
+	gc, err := b.InfoGC()
+	require.NoError(t, err)
+	require.NotEmpty(t, gc.Checkpoints)
+}
+
 func runTestMain(m *testing.M) int {
 	instance, err := test_helpers.StartTarantool(test_helpers.StartOpts{
 		Dialer:       dialer,
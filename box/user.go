@@ -0,0 +1,649 @@
+package box
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+const (
+	vuserSpaceId = 305
+	vprivSpaceId = 313
+)
+
+// Privilege is a bitset of privileges.
+type Privilege uint32
+
+const (
+	PrivilegeRead Privilege = 1 << iota
+	PrivilegeWrite
+	PrivilegeExecute
+	PrivilegeSession
+	PrivilegeUsage
+	PrivilegeCreate
+	PrivilegeDrop
+	PrivilegeAlter
+	PrivilegeReference
+	PrivilegeTrigger
+	PrivilegeInsert
+	PrivilegeUpdate
+	PrivilegeDelete
+	// PrivilegeAll is all privileges.
+	PrivilegeAll Privilege = math.MaxUint32
+)
+
+var privilegeNames = []struct {
+	privilege Privilege
+	name      string
+}{
+	{PrivilegeRead, "read"},
+	{PrivilegeWrite, "write"},
+	{PrivilegeExecute, "execute"},
+	{PrivilegeSession, "session"},
+	{PrivilegeUsage, "usage"},
+	{PrivilegeCreate, "create"},
+	{PrivilegeDrop, "drop"},
+	{PrivilegeAlter, "alter"},
+	{PrivilegeReference, "reference"},
+	{PrivilegeTrigger, "trigger"},
+	{PrivilegeInsert, "insert"},
+	{PrivilegeUpdate, "update"},
+	{PrivilegeDelete, "delete"},
+}
+
+// String returns a comma-separated list of privilege names as Tarantool
+// accepts it in box.schema.user.grant(). Unnamed bits are skipped.
+func (p Privilege) String() string {
+	if p == PrivilegeAll {
+		return "all"
+	}
+	names := make([]string, 0, len(privilegeNames))
+	for _, named := range privilegeNames {
+		if p&named.privilege != 0 {
+			names = append(names, named.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// ParsePrivilege parses a comma-separated list of privilege names.
+func ParsePrivilege(s string) (Privilege, error) {
+	var p Privilege
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			return PrivilegeAll, nil
+		}
+		found := false
+		for _, named := range privilegeNames {
+			if named.name == name {
+				p |= named.privilege
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown privilege %q", name)
+		}
+	}
+	return p, nil
+}
+
+// ObjectType is a type of an object of privileges.
+type ObjectType string
+
+const (
+	ObjectUniverse  ObjectType = "universe"
+	ObjectSpace     ObjectType = "space"
+	ObjectFunction  ObjectType = "function"
+	ObjectSequence  ObjectType = "sequence"
+	ObjectRole      ObjectType = "role"
+	ObjectUser      ObjectType = "user"
+	ObjectLuaCall   ObjectType = "lua_call"
+	ObjectLuaEval   ObjectType = "lua_eval"
+	ObjectSQL       ObjectType = "sql"
+	ObjectCollation ObjectType = "collation"
+)
+
+// objectName returns a name of an object as an argument of a grant
+// function. An empty name means nil: the universe or all objects of a type.
+func objectName(name string) interface{} {
+	if name == "" {
+		return nil
+	}
+	return name
+}
+
+// UserCreateOpts are options of box.schema.user.create().
+type UserCreateOpts struct {
+	Password string `msgpack:"password,omitempty"`
+	// IfNotExists disables an error if the user already exists.
+	IfNotExists bool `msgpack:"if_not_exists,omitempty"`
+}
+
+// UserDropOpts are options of box.schema.user.drop() and
+// box.schema.role.drop().
+type UserDropOpts struct {
+	// IfExists disables an error if the user does not exist.
+	IfExists bool `msgpack:"if_exists,omitempty"`
+}
+
+// RoleCreateOpts are options of box.schema.role.create().
+type RoleCreateOpts struct {
+	// IfNotExists disables an error if the role already exists.
+	IfNotExists bool `msgpack:"if_not_exists,omitempty"`
+}
+
+// GrantOpts are options of box.schema.user.grant() and
+// box.schema.role.grant().
+type GrantOpts struct {
+	// Grantor is a name of a user on behalf of which privileges are
+	// granted.
+	Grantor string `msgpack:"grantor,omitempty"`
+	// IfNotExists disables an error if privileges are already granted.
+	IfNotExists bool `msgpack:"if_not_exists,omitempty"`
+}
+
+// RevokeOpts are options of box.schema.user.revoke() and
+// box.schema.role.revoke().
+type RevokeOpts struct {
+	// IfExists disables an error if privileges are not granted.
+	IfExists bool `msgpack:"if_exists,omitempty"`
+}
+
+// UserCreateRequest creates a user with box.schema.user.create().
+type UserCreateRequest struct {
+	callRequest
+}
+
+// NewUserCreateRequest returns a new request to create a user.
+func NewUserCreateRequest(name string, opts UserCreateOpts) *UserCreateRequest {
+	return &UserCreateRequest{newCallRequest("box.schema.user.create", name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserCreateRequest) Context(ctx context.Context) *UserCreateRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// UserDropRequest drops a user with box.schema.user.drop().
+type UserDropRequest struct {
+	callRequest
+}
+
+// NewUserDropRequest returns a new request to drop a user.
+func NewUserDropRequest(name string, opts UserDropOpts) *UserDropRequest {
+	return &UserDropRequest{newCallRequest("box.schema.user.drop", name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserDropRequest) Context(ctx context.Context) *UserDropRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// UserPasswdRequest sets a password of a user with box.schema.user.passwd().
+type UserPasswdRequest struct {
+	callRequest
+}
+
+// NewUserPasswdRequest returns a new request to set a password of a user.
+func NewUserPasswdRequest(name, password string) *UserPasswdRequest {
+	return &UserPasswdRequest{newCallRequest("box.schema.user.passwd", name, password)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserPasswdRequest) Context(ctx context.Context) *UserPasswdRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// UserExistsRequest checks that a user exists with
+// box.schema.user.exists(). The response data contains a boolean.
+type UserExistsRequest struct {
+	callRequest
+}
+
+// NewUserExistsRequest returns a new request to check that a user exists.
+func NewUserExistsRequest(name string) *UserExistsRequest {
+	return &UserExistsRequest{newCallRequest("box.schema.user.exists", name)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserExistsRequest) Context(ctx context.Context) *UserExistsRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// UserInfoRequest gets privileges of a user with box.schema.user.info().
+// The response data could be decoded into UserInfoResponse.
+type UserInfoRequest struct {
+	callRequest
+}
+
+// NewUserInfoRequest returns a new request to get privileges of a user.
+func NewUserInfoRequest(name string) *UserInfoRequest {
+	return &UserInfoRequest{newCallRequest("box.schema.user.info", name)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserInfoRequest) Context(ctx context.Context) *UserInfoRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// Grant is privileges on an object.
+type Grant struct {
+	Privileges Privilege
+	ObjectType ObjectType
+	// ObjectName is empty for the universe or for all objects of a type.
+	ObjectName string
+}
+
+// DecodeMsgpack decodes a grant in the box.schema.user.info() format:
+// {privileges, object type, object name}.
+func (g *Grant) DecodeMsgpack(d *msgpack.Decoder) error {
+	arrayLen, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if arrayLen < 2 {
+		return fmt.Errorf("protocol violation; expected at least 2 grant fields, got %d", arrayLen)
+	}
+	privileges, err := d.DecodeString()
+	if err != nil {
+		return err
+	}
+	if g.Privileges, err = ParsePrivilege(privileges); err != nil {
+		return err
+	}
+	objectType, err := d.DecodeString()
+	if err != nil {
+		return err
+	}
+	g.ObjectType = ObjectType(objectType)
+	// A name of the universe is nil, so it could be omitted.
+	g.ObjectName = ""
+	if arrayLen > 2 {
+		name, err := d.DecodeInterface()
+		if err != nil {
+			return err
+		}
+		g.ObjectName, _ = name.(string)
+	}
+	for i := 3; i < arrayLen; i++ {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UserInfoResponse is a response to UserInfoRequest.
+type UserInfoResponse struct {
+	Grants []Grant
+}
+
+// DecodeMsgpack decodes the response data.
+func (r *UserInfoResponse) DecodeMsgpack(d *msgpack.Decoder) error {
+	arrayLen, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if arrayLen != 1 {
+		return fmt.Errorf("protocol violation; expected 1 array entry, got %d", arrayLen)
+	}
+	r.Grants = nil
+	return d.Decode(&r.Grants)
+}
+
+// UserGrantRequest grants privileges to a user with box.schema.user.grant().
+type UserGrantRequest struct {
+	callRequest
+}
+
+// NewUserGrantRequest returns a new request to grant privileges on an
+// object to a user. An empty object name means the universe or all objects
+// of the type.
+func NewUserGrantRequest(user string, privileges Privilege, objectType ObjectType,
+	object string, opts GrantOpts) *UserGrantRequest {
+	return &UserGrantRequest{newCallRequest("box.schema.user.grant", user,
+		privileges.String(), string(objectType), objectName(object), opts)}
+}
+
+// NewUserGrantRoleRequest returns a new request to grant a role to a user.
+func NewUserGrantRoleRequest(user, role string, opts GrantOpts) *UserGrantRequest {
+	return &UserGrantRequest{newCallRequest("box.schema.user.grant", user, role,
+		nil, nil, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserGrantRequest) Context(ctx context.Context) *UserGrantRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// UserRevokeRequest revokes privileges from a user with
+// box.schema.user.revoke().
+type UserRevokeRequest struct {
+	callRequest
+}
+
+// NewUserRevokeRequest returns a new request to revoke privileges on an
+// object from a user. An empty object name means the universe or all
+// objects of the type.
+func NewUserRevokeRequest(user string, privileges Privilege, objectType ObjectType,
+	object string, opts RevokeOpts) *UserRevokeRequest {
+	return &UserRevokeRequest{newCallRequest("box.schema.user.revoke", user,
+		privileges.String(), string(objectType), objectName(object), opts)}
+}
+
+// NewUserRevokeRoleRequest returns a new request to revoke a role from a
+// user.
+func NewUserRevokeRoleRequest(user, role string, opts RevokeOpts) *UserRevokeRequest {
+	return &UserRevokeRequest{newCallRequest("box.schema.user.revoke", user, role,
+		nil, nil, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *UserRevokeRequest) Context(ctx context.Context) *UserRevokeRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// RoleCreateRequest creates a role with box.schema.role.create().
+type RoleCreateRequest struct {
+	callRequest
+}
+
+// NewRoleCreateRequest returns a new request to create a role.
+func NewRoleCreateRequest(name string, opts RoleCreateOpts) *RoleCreateRequest {
+	return &RoleCreateRequest{newCallRequest("box.schema.role.create", name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *RoleCreateRequest) Context(ctx context.Context) *RoleCreateRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// RoleDropRequest drops a role with box.schema.role.drop().
+type RoleDropRequest struct {
+	callRequest
+}
+
+// NewRoleDropRequest returns a new request to drop a role.
+func NewRoleDropRequest(name string, opts UserDropOpts) *RoleDropRequest {
+	return &RoleDropRequest{newCallRequest("box.schema.role.drop", name, opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *RoleDropRequest) Context(ctx context.Context) *RoleDropRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// RoleGrantRequest grants privileges to a role with box.schema.role.grant().
+type RoleGrantRequest struct {
+	callRequest
+}
+
+// NewRoleGrantRequest returns a new request to grant privileges on an
+// object to a role. An empty object name means the universe or all objects
+// of the type.
+func NewRoleGrantRequest(role string, privileges Privilege, objectType ObjectType,
+	object string, opts GrantOpts) *RoleGrantRequest {
+	return &RoleGrantRequest{newCallRequest("box.schema.role.grant", role,
+		privileges.String(), string(objectType), objectName(object), opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *RoleGrantRequest) Context(ctx context.Context) *RoleGrantRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// RoleRevokeRequest revokes privileges from a role with
+// box.schema.role.revoke().
+type RoleRevokeRequest struct {
+	callRequest
+}
+
+// NewRoleRevokeRequest returns a new request to revoke privileges on an
+// object from a role. An empty object name means the universe or all
+// objects of the type.
+func NewRoleRevokeRequest(role string, privileges Privilege, objectType ObjectType,
+	object string, opts RevokeOpts) *RoleRevokeRequest {
+	return &RoleRevokeRequest{newCallRequest("box.schema.role.revoke", role,
+		privileges.String(), string(objectType), objectName(object), opts)}
+}
+
+// Context sets a passed context to the request.
+func (req *RoleRevokeRequest) Context(ctx context.Context) *RoleRevokeRequest {
+	req.call.Context(ctx)
+	return req
+}
+
+// User is a tuple of the _user space.
+type User struct {
+	Id uint32
+	// Owner is an id of a user that created the user.
+	Owner uint32
+	Name  string
+	// Type is "user" or "role".
+	Type string
+	// Auth is a map of authentication methods to data. It is empty for
+	// users of the _vuser space without access to the _user space.
+	Auth map[string]interface{}
+}
+
+// DecodeMsgpack decodes a tuple of the _user space.
+func (u *User) DecodeMsgpack(d *msgpack.Decoder) error {
+	arrayLen, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if arrayLen < 4 {
+		return fmt.Errorf("protocol violation; expected at least 4 user fields, got %d",
+			arrayLen)
+	}
+	if u.Id, err = d.DecodeUint32(); err != nil {
+		return err
+	}
+	if u.Owner, err = d.DecodeUint32(); err != nil {
+		return err
+	}
+	if u.Name, err = d.DecodeString(); err != nil {
+		return err
+	}
+	if u.Type, err = d.DecodeString(); err != nil {
+		return err
+	}
+	u.Auth = nil
+	if arrayLen > 4 {
+		code, err := d.PeekCode()
+		if err != nil {
+			return err
+		}
+		// An empty Lua table is encoded as an array.
+		if msgpcode.IsFixedMap(code) || code == msgpcode.Map16 || code == msgpcode.Map32 {
+			err = d.Decode(&u.Auth)
+		} else {
+			err = d.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for i := 5; i < arrayLen; i++ {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Priv is a tuple of the _priv space.
+type Priv struct {
+	// Grantor is an id of a user that granted the privileges.
+	Grantor uint32
+	// Grantee is an id of a user or a role.
+	Grantee    uint32
+	ObjectType ObjectType
+	// ObjectId is an id of an object. It is 0 for the universe.
+	ObjectId uint32
+	// AllObjects is true if the privileges are granted on all objects of
+	// the type.
+	AllObjects bool
+	Privileges Privilege
+}
+
+// DecodeMsgpack decodes a tuple of the _priv space.
+func (p *Priv) DecodeMsgpack(d *msgpack.Decoder) error {
+	arrayLen, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if arrayLen < 5 {
+		return fmt.Errorf("protocol violation; expected at least 5 priv fields, got %d",
+			arrayLen)
+	}
+	if p.Grantor, err = d.DecodeUint32(); err != nil {
+		return err
+	}
+	if p.Grantee, err = d.DecodeUint32(); err != nil {
+		return err
+	}
+	objectType, err := d.DecodeString()
+	if err != nil {
+		return err
+	}
+	p.ObjectType = ObjectType(objectType)
+
+	// Privileges on all objects of a type have an empty string id.
+	code, err := d.PeekCode()
+	if err != nil {
+		return err
+	}
+	p.ObjectId, p.AllObjects = 0, msgpcode.IsString(code)
+	if p.AllObjects {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+	} else if p.ObjectId, err = d.DecodeUint32(); err != nil {
+		return err
+	}
+
+	privileges, err := d.DecodeUint32()
+	if err != nil {
+		return err
+	}
+	p.Privileges = Privilege(privileges)
+	for i := 5; i < arrayLen; i++ {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UserCreate creates a user.
+func (b *Box) UserCreate(name string, opts UserCreateOpts) error {
+	return do(b.conn, NewUserCreateRequest(name, opts))
+}
+
+// UserDrop drops a user.
+func (b *Box) UserDrop(name string, opts UserDropOpts) error {
+	return do(b.conn, NewUserDropRequest(name, opts))
+}
+
+// UserPasswd sets a password of a user.
+func (b *Box) UserPasswd(name, password string) error {
+	return do(b.conn, NewUserPasswdRequest(name, password))
+}
+
+// UserExists checks that a user exists.
+func (b *Box) UserExists(name string) (bool, error) {
+	var exists bool
+	err := b.conn.Do(NewUserExistsRequest(name)).GetTyped(&[]interface{}{&exists})
+	return exists, err
+}
+
+// UserInfo returns privileges of a user.
+func (b *Box) UserInfo(name string) ([]Grant, error) {
+	var resp UserInfoResponse
+	if err := b.conn.Do(NewUserInfoRequest(name)).GetTyped(&resp); err != nil {
+		return nil, err
+	}
+	return resp.Grants, nil
+}
+
+// UserGrant grants privileges on an object to a user.
+func (b *Box) UserGrant(user string, privileges Privilege, objectType ObjectType,
+	object string, opts GrantOpts) error {
+	return do(b.conn, NewUserGrantRequest(user, privileges, objectType, object, opts))
+}
+
+// UserRevoke revokes privileges on an object from a user.
+func (b *Box) UserRevoke(user string, privileges Privilege, objectType ObjectType,
+	object string, opts RevokeOpts) error {
+	return do(b.conn, NewUserRevokeRequest(user, privileges, objectType, object, opts))
+}
+
+// RoleCreate creates a role.
+func (b *Box) RoleCreate(name string, opts RoleCreateOpts) error {
+	return do(b.conn, NewRoleCreateRequest(name, opts))
+}
+
+// RoleDrop drops a role.
+func (b *Box) RoleDrop(name string, opts UserDropOpts) error {
+	return do(b.conn, NewRoleDropRequest(name, opts))
+}
+
+// RoleGrant grants privileges on an object to a role.
+func (b *Box) RoleGrant(role string, privileges Privilege, objectType ObjectType,
+	object string, opts GrantOpts) error {
+	return do(b.conn, NewRoleGrantRequest(role, privileges, objectType, object, opts))
+}
+
+// RoleRevoke revokes privileges on an object from a role.
+func (b *Box) RoleRevoke(role string, privileges Privilege, objectType ObjectType,
+	object string, opts RevokeOpts) error {
+	return do(b.conn, NewRoleRevokeRequest(role, privileges, objectType, object, opts))
+}
+
+// Users returns users and roles from the _vuser space. Only users visible to
+// the current user are returned.
+func (b *Box) Users() ([]User, error) {
+	var users []User
+	req := tarantool.NewSelectRequest(vuserSpaceId).
+		Index(0).
+		Limit(math.MaxUint32)
+	if err := b.conn.Do(req).GetTyped(&users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Privileges returns privileges from the _vpriv space. Only privileges
+// visible to the current user are returned.
+func (b *Box) Privileges() ([]Priv, error) {
+	var privs []Priv
+	req := tarantool.NewSelectRequest(vprivSpaceId).
+		Index(0).
+		Limit(math.MaxUint32)
+	if err := b.conn.Do(req).GetTyped(&privs); err != nil {
+		return nil, err
+	}
+	return privs, nil
+}
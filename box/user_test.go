@@ -0,0 +1,214 @@
+package box_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestPrivilege_String(t *testing.T) {
+	assert.Equal(t, "read,write,execute", (box.PrivilegeRead | box.PrivilegeWrite |
+		box.PrivilegeExecute).String())
+	assert.Equal(t, "session,usage", (box.PrivilegeSession | box.PrivilegeUsage).String())
+	assert.Equal(t, "all", box.PrivilegeAll.String())
+	assert.Equal(t, "", box.Privilege(0).String())
+}
+
+func TestParsePrivilege(t *testing.T) {
+	p, err := box.ParsePrivilege("read, write,delete")
+	require.NoError(t, err)
+	assert.Equal(t, box.PrivilegeRead|box.PrivilegeWrite|box.PrivilegeDelete, p)
+
+	p, err = box.ParsePrivilege("all")
+	require.NoError(t, err)
+	assert.Equal(t, box.PrivilegeAll, p)
+
+	_, err = box.ParsePrivilege("read,fly")
+	assert.EqualError(t, err, `unknown privilege "fly"`)
+}
+
+func TestBox_UserRequests(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	b := box.New(conn)
+
+	var mutex sync.Mutex
+	calls := map[string][]interface{}{}
+	record := func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls[req.FunctionName()] = req.Tuple()
+		return nil, nil
+	}
+	for _, function := range []string{
+		"box.schema.user.create",
+		"box.schema.user.passwd",
+		"box.schema.user.grant",
+		"box.schema.user.revoke",
+		"box.schema.role.create",
+		"box.schema.role.grant",
+		"box.schema.user.drop",
+	} {
+		server.HandleCall(function, record)
+	}
+
+	require.NoError(t, b.UserCreate("tenant", box.UserCreateOpts{
+		Password:    "secret",
+		IfNotExists: true,
+	}))
+	require.NoError(t, b.UserPasswd("tenant", "new secret"))
+	require.NoError(t, b.RoleCreate("readers", box.RoleCreateOpts{}))
+	require.NoError(t, b.RoleGrant("readers", box.PrivilegeRead, box.ObjectSpace, "",
+		box.GrantOpts{}))
+	require.NoError(t, b.UserGrant("tenant", box.PrivilegeRead|box.PrivilegeWrite,
+		box.ObjectSpace, "orders", box.GrantOpts{IfNotExists: true}))
+	require.NoError(t, b.UserRevoke("tenant", box.PrivilegeWrite, box.ObjectSpace, "orders",
+		box.RevokeOpts{}))
+	require.NoError(t, b.UserDrop("tenant", box.UserDropOpts{IfExists: true}))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, map[string][]interface{}{
+		"box.schema.user.create": {"tenant", map[string]interface{}{
+			"password":      "secret",
+			"if_not_exists": true,
+		}},
+		"box.schema.user.passwd": {"tenant", "new secret"},
+		"box.schema.role.create": {"readers", map[string]interface{}{}},
+		"box.schema.role.grant": {"readers", "read", "space", nil,
+			map[string]interface{}{}},
+		"box.schema.user.grant": {"tenant", "read,write", "space", "orders",
+			map[string]interface{}{"if_not_exists": true}},
+		"box.schema.user.revoke": {"tenant", "write", "space", "orders",
+			map[string]interface{}{}},
+		"box.schema.user.drop": {"tenant", map[string]interface{}{"if_exists": true}},
+	}, calls)
+}
+
+func TestUserGrantRoleRequest(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+
+	server.HandleCall("box.schema.user.grant",
+		func(req *mockserver.Request) ([]interface{}, error) {
+			assert.Equal(t, []interface{}{"tenant", "readers", nil, nil,
+				map[string]interface{}{}}, req.Tuple())
+			return nil, nil
+		})
+
+	_, err := conn.Do(box.NewUserGrantRoleRequest("tenant", "readers",
+		box.GrantOpts{})).Get()
+	require.NoError(t, err)
+}
+
+func TestBox_UserExists(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	b := box.New(conn)
+
+	server.HandleCall("box.schema.user.exists",
+		func(req *mockserver.Request) ([]interface{}, error) {
+			return []interface{}{req.Tuple()[0] == "admin"}, nil
+		})
+
+	exists, err := b.UserExists("admin")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = b.UserExists("tenant")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestBox_UserInfo(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	b := box.New(conn)
+
+	server.HandleCall("box.schema.user.info",
+		func(req *mockserver.Request) ([]interface{}, error) {
+			return []interface{}{[]interface{}{
+				[]interface{}{"execute", "role", "public"},
+				[]interface{}{"read,write", "space", "orders"},
+				[]interface{}{"session,usage", "universe"},
+			}}, nil
+		})
+
+	grants, err := b.UserInfo("tenant")
+	require.NoError(t, err)
+	assert.Equal(t, []box.Grant{
+		{Privileges: box.PrivilegeExecute, ObjectType: box.ObjectRole, ObjectName: "public"},
+		{
+			Privileges: box.PrivilegeRead | box.PrivilegeWrite,
+			ObjectType: box.ObjectSpace,
+			ObjectName: "orders",
+		},
+		{Privileges: box.PrivilegeSession | box.PrivilegeUsage, ObjectType: box.ObjectUniverse},
+	}, grants)
+}
+
+func TestBox_UsersAndPrivileges(t *testing.T) {
+	server, conn := mockserver.Connect(t, tarantool.Opts{})
+	b := box.New(conn)
+
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		switch req.SpaceId() {
+		case 305:
+			return []interface{}{
+				[]interface{}{0, 1, "guest", "user", map[string]interface{}{}},
+				[]interface{}{2, 1, "public", "role", []interface{}{}},
+				[]interface{}{32, 1, "tenant", "user",
+					map[string]interface{}{"chap-sha1": "hash"}, []interface{}{}, 0},
+			}, nil
+		case 313:
+			return []interface{}{
+				[]interface{}{1, 2, "function", 1, 4},
+				[]interface{}{1, 32, "space", "", 3},
+				[]interface{}{1, 32, "universe", 0, 24},
+			}, nil
+		}
+		return nil, nil
+	})
+
+	users, err := b.Users()
+	require.NoError(t, err)
+	assert.Equal(t, []box.User{
+		{Id: 0, Owner: 1, Name: "guest", Type: "user", Auth: map[string]interface{}{}},
+		{Id: 2, Owner: 1, Name: "public", Type: "role"},
+		{
+			Id:    32,
+			Owner: 1,
+			Name:  "tenant",
+			Type:  "user",
+			Auth:  map[string]interface{}{"chap-sha1": "hash"},
+		},
+	}, users)
+
+	privs, err := b.Privileges()
+	require.NoError(t, err)
+	assert.Equal(t, []box.Priv{
+		{
+			Grantor:    1,
+			Grantee:    2,
+			ObjectType: box.ObjectFunction,
+			ObjectId:   1,
+			Privileges: box.PrivilegeExecute,
+		},
+		{
+			Grantor:    1,
+			Grantee:    32,
+			ObjectType: box.ObjectSpace,
+			AllObjects: true,
+			Privileges: box.PrivilegeRead | box.PrivilegeWrite,
+		},
+		{
+			Grantor:    1,
+			Grantee:    32,
+			ObjectType: box.ObjectUniverse,
+			Privileges: box.PrivilegeSession | box.PrivilegeUsage,
+		},
+	}, privs)
+}
@@ -0,0 +1,233 @@
+package tarantool
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CircuitState is a state of a circuit breaker.
+type CircuitState uint32
+
+const (
+	// CircuitClosed means that requests are sent as usual.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means that requests fail fast with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen means that requests fail fast with ErrCircuitOpen
+	// while a probe PingRequest is in progress.
+	CircuitHalfOpen
+)
+
+const (
+	defaultBreakerWindow      = 10 * time.Second
+	defaultBreakerMinRequests = 20
+	defaultBreakerOpenTimeout = 5 * time.Second
+)
+
+// CircuitBreaker describes a circuit breaker of a connection. It could be
+// set with Opts.CircuitBreaker.
+//
+// The breaker counts completed requests in a time window. It trips to the
+// CircuitOpen state if a ratio of failed or timed out requests in the
+// window reaches ErrorRatio or TimeoutRatio. A request is considered as
+// failed if it has failed with ErrTimeouted, ErrIoError, ErrProtocolError or
+// ErrConnectionNotReady, errors returned by Tarantool are not taken into
+// account. A request is considered as timed out if it has failed with
+// ErrTimeouted or with ErrIoError due to a network timeout. After
+// OpenTimeout or a reconnect the breaker sends a PingRequest in the
+// CircuitHalfOpen state. It closes if the ping succeeds and opens again
+// otherwise.
+type CircuitBreaker struct {
+	// Window is a period of time to count requests. By default, it is 10
+	// seconds.
+	Window time.Duration
+	// MinRequests is a minimal count of completed requests in the window
+	// to trip the breaker. By default, it is 20.
+	MinRequests uint
+	// ErrorRatio is a ratio of failed requests in the range (0, 1] to trip
+	// the breaker. Timed out requests are considered as failed too. Zero
+	// value disables the check.
+	ErrorRatio float64
+	// TimeoutRatio is a ratio of timed out requests in the range (0, 1] to
+	// trip the breaker. Zero value disables the check.
+	TimeoutRatio float64
+	// OpenTimeout is a period of time in the CircuitOpen state before a
+	// probe. By default, it is 5 seconds.
+	OpenTimeout time.Duration
+}
+
+// circuitBreaker tracks results of requests of a connection.
+type circuitBreaker struct {
+	mutex sync.Mutex
+	opts  CircuitBreaker
+	conn  *Connection
+	// state is a CircuitState, it could be read without the mutex.
+	state uint32
+	// probeReq is the only request allowed in the CircuitHalfOpen state.
+	probeReq *PingRequest
+
+	// ioTimeout is true if the connection is broken by a network timeout,
+	// ErrIoError is counted as a timeout until a reconnect.
+	ioTimeout bool
+
+	windowStart time.Time
+	requests    uint
+	errors      uint
+	timeouts    uint
+}
+
+func newCircuitBreaker(conn *Connection, opts CircuitBreaker) (*circuitBreaker, error) {
+	if opts.Window == 0 {
+		opts.Window = defaultBreakerWindow
+	}
+	if opts.MinRequests == 0 {
+		opts.MinRequests = defaultBreakerMinRequests
+	}
+	if opts.OpenTimeout == 0 {
+		opts.OpenTimeout = defaultBreakerOpenTimeout
+	}
+
+	if opts.ErrorRatio == 0 && opts.TimeoutRatio == 0 {
+		return nil, errors.New("CircuitBreaker.ErrorRatio or TimeoutRatio should be specified")
+	}
+	if opts.ErrorRatio < 0 || opts.ErrorRatio > 1 {
+		return nil, errors.New("CircuitBreaker.ErrorRatio should be in (0, 1]")
+	}
+	if opts.TimeoutRatio < 0 || opts.TimeoutRatio > 1 {
+		return nil, errors.New("CircuitBreaker.TimeoutRatio should be in (0, 1]")
+	}
+
+	return &circuitBreaker{
+		opts:        opts,
+		conn:        conn,
+		probeReq:    NewPingRequest(),
+		windowStart: time.Now(),
+	}, nil
+}
+
+// State returns the current state.
+func (b *circuitBreaker) State() CircuitState {
+	return CircuitState(atomic.LoadUint32(&b.state))
+}
+
+// allow returns true if the request could be sent.
+func (b *circuitBreaker) allow(req Request) bool {
+	switch b.State() {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		return req == b.probeReq
+	default:
+		return false
+	}
+}
+
+// sample counts a result of a completed request.
+func (b *circuitBreaker) sample(err error) {
+	if b.State() != CircuitClosed {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.State() != CircuitClosed {
+		return
+	}
+
+	var failed, timeout bool
+	var clientErr ClientError
+	if errors.As(err, &clientErr) {
+		switch clientErr.Code {
+		case ErrTimeouted:
+			failed, timeout = true, true
+		case ErrIoError:
+			failed, timeout = true, b.ioTimeout
+		case ErrProtocolError, ErrConnectionNotReady:
+			failed = true
+		}
+	}
+	if now := time.Now(); now.Sub(b.windowStart) > b.opts.Window {
+		b.reset(now)
+	}
+	b.requests++
+	if failed {
+		b.errors++
+	}
+	if timeout {
+		b.timeouts++
+	}
+
+	if b.requests < b.opts.MinRequests {
+		return
+	}
+	requests := float64(b.requests)
+	if (b.opts.ErrorRatio > 0 && float64(b.errors) >= b.opts.ErrorRatio*requests) ||
+		(b.opts.TimeoutRatio > 0 && float64(b.timeouts) >= b.opts.TimeoutRatio*requests) {
+		b.open()
+	}
+}
+
+// timedOut marks the connection as broken by a network timeout.
+func (b *circuitBreaker) timedOut() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.ioTimeout = true
+}
+
+// connected is called on a reconnect. It sends a probe right away if the
+// breaker is open.
+func (b *circuitBreaker) connected() {
+	b.mutex.Lock()
+	b.ioTimeout = false
+	b.mutex.Unlock()
+
+	if b.State() == CircuitOpen {
+		go b.probe()
+	}
+}
+
+// reset starts a new window. It must be called with the mutex held.
+func (b *circuitBreaker) reset(now time.Time) {
+	b.windowStart = now
+	b.requests, b.errors, b.timeouts = 0, 0, 0
+}
+
+// open trips the breaker and schedules a probe. It must be called with the
+// mutex held.
+func (b *circuitBreaker) open() {
+	atomic.StoreUint32(&b.state, uint32(CircuitOpen))
+	time.AfterFunc(b.opts.OpenTimeout, b.probe)
+}
+
+// probe sends a PingRequest in the CircuitHalfOpen state and closes or
+// opens the breaker according to a result. It does nothing if another probe
+// is in progress or the breaker is closed already. A probe of a closed
+// connection resets the breaker, the connection fails requests by itself.
+func (b *circuitBreaker) probe() {
+	if !atomic.CompareAndSwapUint32(&b.state, uint32(CircuitOpen),
+		uint32(CircuitHalfOpen)) {
+		return
+	}
+	if b.conn.ClosedNow() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		b.reset(time.Now())
+		atomic.StoreUint32(&b.state, uint32(CircuitClosed))
+		return
+	}
+
+	_, err := b.conn.send(b.probeReq, ignoreStreamId).Get()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if err != nil {
+		b.open()
+		return
+	}
+	b.reset(time.Now())
+	atomic.StoreUint32(&b.state, uint32(CircuitClosed))
+}
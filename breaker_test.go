@@ -0,0 +1,158 @@
+package tarantool_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var unhealthy int32 = 1
+	handler := func(req *mockserver.Request) ([]interface{}, error) {
+		if atomic.LoadInt32(&unhealthy) == 1 {
+			return nil, mockserver.ErrNoResponse
+		}
+		return []interface{}{true}, nil
+	}
+	server.HandleCall("work", handler)
+	server.Handle(iproto.IPROTO_PING, handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := Connect(ctx, NetDialer{Address: server.Addr()}, Opts{
+		SkipSchema: true,
+		Timeout:    50 * time.Millisecond,
+		Reconnect:  10 * time.Millisecond,
+		CircuitBreaker: &CircuitBreaker{
+			MinRequests:  4,
+			TimeoutRatio: 0.5,
+			OpenTimeout:  50 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, CircuitClosed, conn.CircuitState())
+
+	// A network timeout breaks the connection, it is counted as a timeout
+	// too. Pings of the connection time out as well, so the breaker could
+	// trip before all requests are sent. A reconnect starts a probe.
+	var clientErr ClientError
+	for i := 0; i < 4; i++ {
+		_, err := conn.Do(NewCallRequest("work")).Get()
+		require.ErrorAs(t, err, &clientErr)
+		assert.Contains(t, []uint32{ErrTimeouted, ErrIoError, ErrConnectionNotReady,
+			ErrCircuitOpen}, clientErr.Code)
+	}
+	require.NotEqual(t, CircuitClosed, conn.CircuitState())
+
+	start := time.Now()
+	_, err = conn.Do(NewCallRequest("work")).Get()
+	require.ErrorAs(t, err, &clientErr)
+	assert.EqualValues(t, ErrCircuitOpen, clientErr.Code)
+	assert.True(t, clientErr.Temporary())
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// Probes fail while the instance is unhealthy.
+	time.Sleep(300 * time.Millisecond)
+	assert.NotEqual(t, CircuitClosed, conn.CircuitState())
+
+	atomic.StoreInt32(&unhealthy, 0)
+	assert.Eventually(t, func() bool {
+		return conn.CircuitState() == CircuitClosed
+	}, 2*time.Second, 10*time.Millisecond)
+
+	data, err := conn.Do(NewCallRequest("work")).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{true}, data)
+}
+
+func TestCircuitBreaker_reconnect(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var unhealthy int32 = 1
+	handler := func(req *mockserver.Request) ([]interface{}, error) {
+		if atomic.LoadInt32(&unhealthy) == 1 {
+			return nil, mockserver.ErrNoResponse
+		}
+		return []interface{}{true}, nil
+	}
+	server.HandleCall("work", handler)
+	server.Handle(iproto.IPROTO_PING, handler)
+
+	conn := server.Connect(t, Opts{
+		Timeout:   50 * time.Millisecond,
+		Reconnect: 10 * time.Millisecond,
+		CircuitBreaker: &CircuitBreaker{
+			MinRequests:  1,
+			TimeoutRatio: 0.1,
+			OpenTimeout:  time.Hour,
+		},
+	})
+
+	_, err := conn.Do(NewCallRequest("work")).Get()
+	require.Error(t, err)
+	time.Sleep(100 * time.Millisecond)
+	assert.NotEqual(t, CircuitClosed, conn.CircuitState())
+
+	// A network timeout breaks the connection. A probe is sent after each
+	// reconnect without waiting for OpenTimeout.
+	atomic.StoreInt32(&unhealthy, 0)
+	assert.Eventually(t, func() bool {
+		return conn.CircuitState() == CircuitClosed
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestCircuitBreaker_errorRatio(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	conn := server.Connect(t, Opts{
+		CircuitBreaker: &CircuitBreaker{
+			MinRequests: 2,
+			ErrorRatio:  0.1,
+			OpenTimeout: time.Hour,
+		},
+	})
+
+	// Errors returned by Tarantool do not trip the breaker.
+	for i := 0; i < 10; i++ {
+		_, err := conn.Do(NewCallRequest("unknown")).Get()
+		var tntErr Error
+		require.ErrorAs(t, err, &tntErr)
+	}
+	assert.Equal(t, CircuitClosed, conn.CircuitState())
+}
+
+func TestCircuitBreaker_opts(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	cases := []struct {
+		name    string
+		breaker CircuitBreaker
+	}{
+		{"no ratio", CircuitBreaker{}},
+		{"invalid error ratio", CircuitBreaker{ErrorRatio: 1.5}},
+		{"invalid timeout ratio", CircuitBreaker{TimeoutRatio: -1}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			breaker := tc.breaker
+			_, err := Connect(ctx, NetDialer{Address: server.Addr()}, Opts{
+				SkipSchema:     true,
+				CircuitBreaker: &breaker,
+			})
+			assert.Error(t, err)
+		})
+	}
+}
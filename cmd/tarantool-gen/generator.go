@@ -0,0 +1,533 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// goType describes a Go representation of a field type.
+type goType struct {
+	// name is a Go type of a non-nullable field.
+	name string
+	// encode is a format of an encoding expression for a value. For arrays
+	// and maps it encodes an element, the container is encoded with a loop.
+	encode string
+	// decode is a decoding method of msgpack.Decoder.
+	decode string
+	// scalar is true if a nullable field is represented with a pointer,
+	// otherwise the type itself could hold nil.
+	scalar bool
+}
+
+// goTypes maps Tarantool field types to Go types. Other types are decoded
+// into interface{}.
+var goTypes = map[string]goType{
+	"unsigned":  {"uint64", "enc.EncodeUint(%s)", "dec.DecodeUint64", true},
+	"integer":   {"int64", "enc.EncodeInt(%s)", "dec.DecodeInt64", true},
+	"number":    {"float64", "enc.EncodeFloat64(%s)", "dec.DecodeFloat64", true},
+	"double":    {"float64", "enc.EncodeFloat64(%s)", "dec.DecodeFloat64", true},
+	"string":    {"string", "enc.EncodeString(%s)", "dec.DecodeString", true},
+	"boolean":   {"bool", "enc.EncodeBool(%s)", "dec.DecodeBool", true},
+	"varbinary": {"[]byte", "enc.EncodeBytes(%s)", "dec.DecodeBytes", false},
+	"array":     {"[]interface{}", "enc.Encode(%s)", "dec.DecodeSlice", false},
+	"map":       {"map[interface{}]interface{}", "enc.Encode(%s)", "dec.DecodeUntypedMap", false},
+}
+
+// Types of containers that are encoded with loops.
+const (
+	arrayType = "array"
+	mapType   = "map"
+)
+
+var anyType = goType{"interface{}", "enc.Encode(%s)", "dec.DecodeInterface", false}
+
+func fieldGoType(typ string) goType {
+	if t, ok := goTypes[strings.ToLower(typ)]; ok {
+		return t
+	}
+	return anyType
+}
+
+// commentWidth is a maximum width of a generated doc comment line.
+const commentWidth = 77
+
+// reservedParams are names of parameters of generated functions.
+var reservedParams = map[string]bool{
+	"ctx":    true,
+	"doer":   true,
+	"req":    true,
+	"tuple":  true,
+	"tuples": true,
+	"err":    true,
+}
+
+// genOpts are options of a code generation.
+type genOpts struct {
+	// Package is a name of a package of the generated code.
+	Package string
+	// Spaces is a list of space names to generate. All user spaces are
+	// generated if it is empty.
+	Spaces []string
+}
+
+// generator generates Go code for spaces of a schema.
+type generator struct {
+	buf bytes.Buffer
+	// names are declared top-level identifiers.
+	names map[string]string
+}
+
+// genField is a field of a generated struct.
+type genField struct {
+	tarantool.Field
+	goName string
+	// container is arrayType or mapType if the field is encoded with a loop.
+	container string
+	typ       goType
+	// pointer is true if a nullable field is represented by a pointer.
+	pointer bool
+}
+
+// genSpace is a space with names of generated identifiers.
+type genSpace struct {
+	tarantool.Space
+	fields []genField
+	// required is a minimal length of a tuple.
+	required int
+	// constName is a name of a constant with the space name.
+	constName string
+	// typeName is a name of a tuple struct.
+	typeName string
+	// pluralName is used in names of functions that return many tuples.
+	pluralName string
+	// listName is a name of a slice of tuples.
+	listName string
+}
+
+// generate returns Go code for spaces of the schema.
+func generate(schema tarantool.Schema, opts genOpts) ([]byte, error) {
+	var spaces []tarantool.Space
+	if len(opts.Spaces) == 0 {
+		spaces = userSpaces(schema)
+	} else {
+		for _, name := range opts.Spaces {
+			space, ok := schema.Spaces[name]
+			if !ok {
+				return nil, fmt.Errorf("space %q not found", name)
+			}
+			if len(space.FieldsById) == 0 {
+				return nil, fmt.Errorf("space %q has no format", name)
+			}
+			spaces = append(spaces, space)
+		}
+	}
+	if len(spaces) == 0 {
+		return nil, errors.New("no spaces with a format to generate")
+	}
+
+	g := &generator{names: make(map[string]string)}
+	genSpaces := make([]genSpace, 0, len(spaces))
+	nullable := false
+	for _, space := range spaces {
+		genSpace, err := g.newGenSpace(space)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range genSpace.fields {
+			nullable = nullable || field.pointer
+		}
+		genSpaces = append(genSpaces, genSpace)
+	}
+
+	g.printf("// Code generated by tarantool-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", opts.Package)
+	g.printf("import (\n")
+	g.printf("\t\"context\"\n\t\"fmt\"\n\n")
+	g.printf("\t\"github.com/vmihailenco/msgpack/v5\"\n")
+	if nullable {
+		g.printf("\t\"github.com/vmihailenco/msgpack/v5/msgpcode\"\n")
+	}
+	g.printf("\n\t\"github.com/tarantool/go-tarantool/v2\"\n")
+	g.printf(")\n")
+
+	for _, space := range genSpaces {
+		if err := g.genSpace(space); err != nil {
+			return nil, err
+		}
+	}
+	if nullable {
+		g.genDecodeNullable()
+	}
+
+	code, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment prints an empty line and a doc comment wrapped by words.
+func (g *generator) comment(format string, args ...interface{}) {
+	g.printf("\n//")
+	width := 2
+	for _, word := range strings.Fields(fmt.Sprintf(format, args...)) {
+		if width > 2 && width+len(word) >= commentWidth {
+			g.printf("\n//")
+			width = 2
+		}
+		g.printf(" %s", word)
+		width += len(word) + 1
+	}
+	g.printf("\n")
+}
+
+// declare registers a top-level identifier of the space.
+func (g *generator) declare(name string, space string) error {
+	if other, ok := g.names[name]; ok {
+		return fmt.Errorf("identifier %s of space %q conflicts with space %q",
+			name, space, other)
+	}
+	g.names[name] = space
+	return nil
+}
+
+func (g *generator) newGenSpace(space tarantool.Space) (genSpace, error) {
+	s := genSpace{
+		Space:      space,
+		constName:  exportedName(space.Name) + "Space",
+		typeName:   singular(exportedName(space.Name)),
+		pluralName: exportedName(space.Name),
+	}
+	if s.pluralName == s.typeName {
+		s.pluralName += "List"
+	}
+	s.listName = unexportedName(s.typeName) + "List"
+
+	names := make(map[string]bool)
+	for i, field := range spaceFields(space) {
+		if field.Id != uint32(i) {
+			return s, fmt.Errorf("space %q has a gap in the format at field %d",
+				space.Name, i)
+		}
+		f := genField{
+			Field:  field,
+			goName: exportedName(field.Name),
+			typ:    fieldGoType(field.Type),
+		}
+		if names[f.goName] {
+			f.goName = fmt.Sprintf("%s%d", f.goName, field.Id)
+		}
+		names[f.goName] = true
+		if typ := strings.ToLower(field.Type); typ == arrayType || typ == mapType {
+			f.container = typ
+		}
+		f.pointer = field.IsNullable && f.typ.scalar
+		if !field.IsNullable {
+			s.required = i + 1
+		}
+		s.fields = append(s.fields, f)
+	}
+
+	for _, name := range []string{s.constName, s.typeName, s.listName,
+		"Insert" + s.typeName, "Replace" + s.typeName} {
+		if err := g.declare(name, space.Name); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+func (g *generator) genSpace(s genSpace) error {
+	g.comment("%s is a name of the %q space.", s.constName, s.Name)
+	g.printf("const %s = %q\n", s.constName, s.Name)
+
+	g.comment("%s is a tuple of the %q space.", s.typeName, s.Name)
+	g.printf("type %s struct {\n", s.typeName)
+	for _, f := range s.fields {
+		typ := f.typ.name
+		if f.pointer {
+			typ = "*" + typ
+		}
+		g.printf("\t%s %s\n", f.goName, typ)
+	}
+	g.printf("}\n")
+
+	g.genEncode(s)
+	g.genDecode(s)
+	g.genList(s)
+	g.genWrite(s, "Insert", "inserts")
+	g.genWrite(s, "Replace", "inserts or replaces")
+
+	for _, index := range spaceIndexes(s.Space) {
+		if err := g.genIndex(s, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) genEncode(s genSpace) {
+	g.comment("EncodeMsgpack encodes the tuple.")
+	g.printf("func (t *%s) EncodeMsgpack(enc *msgpack.Encoder) error {\n", s.typeName)
+	g.printf("\tif err := enc.EncodeArrayLen(%d); err != nil {\n", len(s.fields))
+	g.printf("\t\treturn err\n\t}\n")
+	for _, f := range s.fields {
+		value := "t." + f.goName
+		if f.pointer {
+			g.printf("\tif %s == nil {\n", value)
+			g.printf("\t\tif err := enc.EncodeNil(); err != nil {\n\t\t\treturn err\n\t\t}\n")
+			g.printf("\t} else if err := %s; err != nil {\n",
+				fmt.Sprintf(f.typ.encode, "*"+value))
+			g.printf("\t\treturn err\n\t}\n")
+			continue
+		}
+		if f.container != "" {
+			g.genEncodeContainer(f, value)
+			continue
+		}
+		g.printf("\tif err := %s; err != nil {\n", fmt.Sprintf(f.typ.encode, value))
+		g.printf("\t\treturn err\n\t}\n")
+	}
+	g.printf("\treturn nil\n}\n")
+}
+
+// genEncodeContainer generates a loop that encodes elements of an array or
+// a map without the reflection on the container.
+func (g *generator) genEncodeContainer(f genField, value string) {
+	g.printf("\tif %s == nil {\n", value)
+	g.printf("\t\tif err := enc.EncodeNil(); err != nil {\n\t\t\treturn err\n\t\t}\n")
+	if f.container == arrayType {
+		g.printf("\t} else if err := enc.EncodeArrayLen(len(%s)); err != nil {\n", value)
+		g.printf("\t\treturn err\n")
+		g.printf("\t} else {\n")
+		g.printf("\t\tfor _, v := range %s {\n", value)
+		g.printf("\t\t\tif err := %s; err != nil {\n", fmt.Sprintf(f.typ.encode, "v"))
+		g.printf("\t\t\t\treturn err\n\t\t\t}\n")
+		g.printf("\t\t}\n\t}\n")
+		return
+	}
+	g.printf("\t} else if err := enc.EncodeMapLen(len(%s)); err != nil {\n", value)
+	g.printf("\t\treturn err\n")
+	g.printf("\t} else {\n")
+	g.printf("\t\tfor k, v := range %s {\n", value)
+	g.printf("\t\t\tif err := %s; err != nil {\n", fmt.Sprintf(f.typ.encode, "k"))
+	g.printf("\t\t\t\treturn err\n\t\t\t}\n")
+	g.printf("\t\t\tif err := %s; err != nil {\n", fmt.Sprintf(f.typ.encode, "v"))
+	g.printf("\t\t\t\treturn err\n\t\t\t}\n")
+	g.printf("\t\t}\n\t}\n")
+}
+
+func (g *generator) genDecode(s genSpace) {
+	g.comment("DecodeMsgpack decodes the tuple.")
+	g.printf("func (t *%s) DecodeMsgpack(dec *msgpack.Decoder) error {\n", s.typeName)
+	g.printf("\tl, err := dec.DecodeArrayLen()\n")
+	g.printf("\tif err != nil {\n\t\treturn err\n\t}\n")
+	g.printf("\tif l < %d {\n", s.required)
+	g.printf("\t\treturn fmt.Errorf(%q, l)\n",
+		fmt.Sprintf("%s: unexpected tuple length %%d", s.Name))
+	g.printf("\t}\n\n")
+	g.printf("\t*t = %s{}\n", s.typeName)
+	g.printf("\tfor i := 0; i < l; i++ {\n")
+	g.printf("\t\tswitch i {\n")
+	for _, f := range s.fields {
+		decode := f.typ.decode + "()"
+		if f.pointer {
+			decode = fmt.Sprintf("decodeNullable(dec, %s)", f.typ.decode)
+		}
+		g.printf("\t\tcase %d:\n", f.Id)
+		g.printf("\t\t\tif t.%s, err = %s; err != nil {\n", f.goName, decode)
+		g.printf("\t\t\t\treturn fmt.Errorf(%q, err)\n",
+			fmt.Sprintf("%s: failed to decode field %q: %%w", s.Name, f.Name))
+		g.printf("\t\t\t}\n")
+	}
+	g.printf("\t\tdefault:\n")
+	g.printf("\t\t\tif err = dec.Skip(); err != nil {\n\t\t\t\treturn err\n\t\t\t}\n")
+	g.printf("\t\t}\n\t}\n")
+	g.printf("\treturn nil\n}\n")
+}
+
+func (g *generator) genList(s genSpace) {
+	g.comment("%s is a list of tuples of the %q space.", s.listName, s.Name)
+	g.printf("type %s []%s\n", s.listName, s.typeName)
+
+	g.comment("DecodeMsgpack decodes the tuples.")
+	g.printf("func (l *%s) DecodeMsgpack(dec *msgpack.Decoder) error {\n", s.listName)
+	g.printf("\tn, err := dec.DecodeArrayLen()\n")
+	g.printf("\tif err != nil {\n\t\treturn err\n\t}\n")
+	g.printf("\tif n <= 0 {\n\t\t*l = nil\n\t\treturn nil\n\t}\n\n")
+	g.printf("\t*l = make(%s, n)\n", s.listName)
+	g.printf("\tfor i := range *l {\n")
+	g.printf("\t\tif err := (*l)[i].DecodeMsgpack(dec); err != nil {\n")
+	g.printf("\t\t\treturn err\n\t\t}\n\t}\n")
+	g.printf("\treturn nil\n}\n")
+
+	g.comment("first returns the first tuple of a response or nil.")
+	g.printf("func (l *%s) first(fut *tarantool.Future) (*%s, error) {\n",
+		s.listName, s.typeName)
+	g.printf("\tif err := fut.GetTyped(l); err != nil {\n\t\treturn nil, err\n\t}\n")
+	g.printf("\tif len(*l) == 0 {\n\t\treturn nil, nil\n\t}\n")
+	g.printf("\treturn &(*l)[0], nil\n}\n")
+}
+
+func (g *generator) genWrite(s genSpace, op, verb string) {
+	g.comment("%s%s %s the tuple into the %q space and returns the new tuple.",
+		op, s.typeName, verb, s.Name)
+	g.printf("func %s%s(ctx context.Context, doer tarantool.Doer, tuple *%s) (*%s, error) {\n",
+		op, s.typeName, s.typeName, s.typeName)
+	g.printf("\treq := tarantool.New%sRequest(%s).Tuple(tuple).Context(ctx)\n",
+		op, s.constName)
+	g.printf("\tvar tuples %s\n", s.listName)
+	g.printf("\treturn tuples.first(doer.Do(req))\n}\n")
+}
+
+// genIndex generates functions for a TREE or HASH index: Get<Type>By<Index>
+// and Delete<Type>By<Index> for an unique index or
+// Select<Plural>By<Index> for a non-unique one.
+func (g *generator) genIndex(s genSpace, index tarantool.Index) error {
+	indexType := strings.ToLower(index.Type)
+	if indexType != "tree" && indexType != "hash" {
+		return nil
+	}
+
+	indexName := exportedName(index.Name)
+	var params, keys []string
+	for i, part := range index.Fields {
+		name := fmt.Sprintf("field%d", part.Id)
+		if field, ok := s.FieldsById[part.Id]; ok {
+			name = unexportedName(field.Name)
+		}
+		if reservedParams[name] || token.IsKeyword(name) {
+			name += "Key"
+		}
+		for _, param := range params {
+			if strings.HasPrefix(param, name+" ") {
+				name = fmt.Sprintf("%s%d", name, i)
+			}
+		}
+		params = append(params, name+" "+fieldGoType(part.Type).name)
+		keys = append(keys, name)
+	}
+	key := fmt.Sprintf("[]interface{}{%s}", strings.Join(keys, ", "))
+	signature := fmt.Sprintf("(ctx context.Context, doer tarantool.Doer, %s)",
+		strings.Join(params, ", "))
+
+	if !index.Unique {
+		name := "Select" + s.pluralName + "By" + indexName
+		if err := g.declare(name, s.Name); err != nil {
+			return err
+		}
+		g.comment("%s returns tuples of the %q space by the %q index.",
+			name, s.Name, index.Name)
+		g.printf("func %s%s ([]%s, error) {\n", name, signature, s.typeName)
+		g.printf("\treq := tarantool.NewSelectRequest(%s).\n", s.constName)
+		g.printf("\t\tIndex(%q).\n", index.Name)
+		g.printf("\t\tIterator(tarantool.IterEq).\n")
+		g.printf("\t\tKey(%s).\n", key)
+		g.printf("\t\tContext(ctx)\n")
+		g.printf("\tvar tuples %s\n", s.listName)
+		g.printf("\terr := doer.Do(req).GetTyped(&tuples)\n")
+		g.printf("\treturn tuples, err\n}\n")
+		return nil
+	}
+
+	name := "Get" + s.typeName + "By" + indexName
+	if err := g.declare(name, s.Name); err != nil {
+		return err
+	}
+	g.comment("%s returns a tuple of the %q space by the %q index or nil "+
+		"if the tuple is not found.", name, s.Name, index.Name)
+	g.printf("func %s%s (*%s, error) {\n", name, signature, s.typeName)
+	g.printf("\treq := tarantool.NewSelectRequest(%s).\n", s.constName)
+	g.printf("\t\tIndex(%q).\n", index.Name)
+	g.printf("\t\tIterator(tarantool.IterEq).\n")
+	g.printf("\t\tLimit(1).\n")
+	g.printf("\t\tKey(%s).\n", key)
+	g.printf("\t\tContext(ctx)\n")
+	g.printf("\tvar tuples %s\n", s.listName)
+	g.printf("\treturn tuples.first(doer.Do(req))\n}\n")
+
+	name = "Delete" + s.typeName + "By" + indexName
+	if err := g.declare(name, s.Name); err != nil {
+		return err
+	}
+	g.comment("%s deletes a tuple of the %q space by the %q index and "+
+		"returns the deleted tuple or nil if the tuple is not found.",
+		name, s.Name, index.Name)
+	g.printf("func %s%s (*%s, error) {\n", name, signature, s.typeName)
+	g.printf("\treq := tarantool.NewDeleteRequest(%s).\n", s.constName)
+	g.printf("\t\tIndex(%q).\n", index.Name)
+	g.printf("\t\tKey(%s).\n", key)
+	g.printf("\t\tContext(ctx)\n")
+	g.printf("\tvar tuples %s\n", s.listName)
+	g.printf("\treturn tuples.first(doer.Do(req))\n}\n")
+	return nil
+}
+
+func (g *generator) genDecodeNullable() {
+	g.comment("decodeNullable decodes a nullable value with the decode function.")
+	g.printf("func decodeNullable[T any](dec *msgpack.Decoder, " +
+		"decode func() (T, error)) (*T, error) {\n")
+	g.printf("\tcode, err := dec.PeekCode()\n")
+	g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	g.printf("\tif code == msgpcode.Nil {\n\t\treturn nil, dec.DecodeNil()\n\t}\n\n")
+	g.printf("\tv, err := decode()\n")
+	g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	g.printf("\treturn &v, nil\n}\n")
+}
+
+// exportedName converts a name like "user_id" into "UserId".
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	exported := b.String()
+	if exported == "" || !unicode.IsLetter([]rune(exported)[0]) {
+		exported = "X" + exported
+	}
+	return exported
+}
+
+// unexportedName converts a name like "user_id" into "userId".
+func unexportedName(name string) string {
+	exported := []rune(exportedName(name))
+	exported[0] = unicode.ToLower(exported[0])
+	return string(exported)
+}
+
+// singular returns a singular form of an English plural noun.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "ss"), strings.HasSuffix(name, "us"):
+		return name
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name
+}
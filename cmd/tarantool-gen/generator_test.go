@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+const (
+	testSchema    = "testdata/schema.json"
+	testGenerated = "internal/example/example_gen.go"
+)
+
+func TestGenerate_upToDate(t *testing.T) {
+	schema, err := loadSchemaDump(testSchema)
+	require.NoError(t, err)
+
+	code, err := generate(schema, genOpts{Package: "example"})
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(testGenerated)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(code),
+		"run go generate ./cmd/tarantool-gen/...")
+}
+
+func TestRun_schema(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "model_gen.go")
+	err := run([]string{"-schema", testSchema, "-package", "example", "-out", out})
+	require.NoError(t, err)
+
+	code, err := os.ReadFile(out)
+	require.NoError(t, err)
+	expected, err := os.ReadFile(testGenerated)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(code))
+}
+
+func TestRun_noSource(t *testing.T) {
+	err := run([]string{"-package", "example"})
+	assert.EqualError(t, err, "-addr or -schema should be specified")
+}
+
+func TestSchemaDump(t *testing.T) {
+	data, err := os.ReadFile(testSchema)
+	require.NoError(t, err)
+
+	var dump schemaDump
+	require.NoError(t, json.Unmarshal(data, &dump))
+
+	path := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, saveSchemaDump(path, dump.schema()))
+
+	saved, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(saved))
+}
+
+func TestSchemaDump_skipsSystemSpaces(t *testing.T) {
+	field := tarantool.Field{Id: 0, Name: "id", Type: "unsigned"}
+	fields := map[uint32]tarantool.Field{0: field}
+	schema := tarantool.Schema{
+		SpacesById: map[uint32]tarantool.Space{
+			280: {Id: 280, Name: "_space", FieldsById: fields},
+			512: {Id: 512, Name: "noformat"},
+			513: {Id: 513, Name: "users", FieldsById: fields},
+		},
+	}
+
+	dump := newSchemaDump(schema)
+	require.Len(t, dump.Spaces, 1)
+	assert.Equal(t, "users", dump.Spaces[0].Name)
+}
+
+func TestGenerate_errors(t *testing.T) {
+	schema, err := loadSchemaDump(testSchema)
+	require.NoError(t, err)
+
+	_, err = generate(schema, genOpts{Package: "example", Spaces: []string{"orders"}})
+	assert.EqualError(t, err, `space "orders" not found`)
+
+	users := schema.Spaces["users"]
+	conflict := users
+	conflict.Id, conflict.Name = 513, "user"
+	schema.Spaces[conflict.Name] = conflict
+	schema.SpacesById[conflict.Id] = conflict
+
+	_, err = generate(schema, genOpts{Package: "example"})
+	assert.EqualError(t, err,
+		`identifier User of space "users" conflicts with space "user"`)
+}
+
+func TestNames(t *testing.T) {
+	cases := []struct {
+		name       string
+		exported   string
+		unexported string
+		singular   string
+	}{
+		{"users", "Users", "users", "User"},
+		{"user_id", "UserId", "userId", "UserId"},
+		{"order-items", "OrderItems", "orderItems", "OrderItem"},
+		{"categories", "Categories", "categories", "Category"},
+		{"addresses", "Addresses", "addresses", "Address"},
+		{"boxes", "Boxes", "boxes", "Box"},
+		{"status", "Status", "status", "Status"},
+		{"1st", "X1st", "x1st", "X1st"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.exported, exportedName(tc.name))
+			assert.Equal(t, tc.unexported, unexportedName(tc.name))
+			assert.Equal(t, tc.singular, singular(exportedName(tc.name)))
+		})
+	}
+}
@@ -0,0 +1,5 @@
+// Package example contains code generated by tarantool-gen from
+// testdata/schema.json. It is used to test the generated code.
+package example
+
+//go:generate go run ../.. -schema ../../testdata/schema.json -package example -out example_gen.go
@@ -0,0 +1,252 @@
+// Code generated by tarantool-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// UsersSpace is a name of the "users" space.
+const UsersSpace = "users"
+
+// User is a tuple of the "users" space.
+type User struct {
+	Id       uint64
+	Email    string
+	Name     string
+	Age      *uint64
+	Tags     []interface{}
+	Settings map[interface{}]interface{}
+}
+
+// EncodeMsgpack encodes the tuple.
+func (t *User) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeArrayLen(6); err != nil {
+		return err
+	}
+	if err := enc.EncodeUint(t.Id); err != nil {
+		return err
+	}
+	if err := enc.EncodeString(t.Email); err != nil {
+		return err
+	}
+	if err := enc.EncodeString(t.Name); err != nil {
+		return err
+	}
+	if t.Age == nil {
+		if err := enc.EncodeNil(); err != nil {
+			return err
+		}
+	} else if err := enc.EncodeUint(*t.Age); err != nil {
+		return err
+	}
+	if t.Tags == nil {
+		if err := enc.EncodeNil(); err != nil {
+			return err
+		}
+	} else if err := enc.EncodeArrayLen(len(t.Tags)); err != nil {
+		return err
+	} else {
+		for _, v := range t.Tags {
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+	}
+	if t.Settings == nil {
+		if err := enc.EncodeNil(); err != nil {
+			return err
+		}
+	} else if err := enc.EncodeMapLen(len(t.Settings)); err != nil {
+		return err
+	} else {
+		for k, v := range t.Settings {
+			if err := enc.Encode(k); err != nil {
+				return err
+			}
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack decodes the tuple.
+func (t *User) DecodeMsgpack(dec *msgpack.Decoder) error {
+	l, err := dec.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if l < 3 {
+		return fmt.Errorf("users: unexpected tuple length %d", l)
+	}
+
+	*t = User{}
+	for i := 0; i < l; i++ {
+		switch i {
+		case 0:
+			if t.Id, err = dec.DecodeUint64(); err != nil {
+				return fmt.Errorf("users: failed to decode field \"id\": %w", err)
+			}
+		case 1:
+			if t.Email, err = dec.DecodeString(); err != nil {
+				return fmt.Errorf("users: failed to decode field \"email\": %w", err)
+			}
+		case 2:
+			if t.Name, err = dec.DecodeString(); err != nil {
+				return fmt.Errorf("users: failed to decode field \"name\": %w", err)
+			}
+		case 3:
+			if t.Age, err = decodeNullable(dec, dec.DecodeUint64); err != nil {
+				return fmt.Errorf("users: failed to decode field \"age\": %w", err)
+			}
+		case 4:
+			if t.Tags, err = dec.DecodeSlice(); err != nil {
+				return fmt.Errorf("users: failed to decode field \"tags\": %w", err)
+			}
+		case 5:
+			if t.Settings, err = dec.DecodeUntypedMap(); err != nil {
+				return fmt.Errorf("users: failed to decode field \"settings\": %w", err)
+			}
+		default:
+			if err = dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// userList is a list of tuples of the "users" space.
+type userList []User
+
+// DecodeMsgpack decodes the tuples.
+func (l *userList) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		*l = nil
+		return nil
+	}
+
+	*l = make(userList, n)
+	for i := range *l {
+		if err := (*l)[i].DecodeMsgpack(dec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// first returns the first tuple of a response or nil.
+func (l *userList) first(fut *tarantool.Future) (*User, error) {
+	if err := fut.GetTyped(l); err != nil {
+		return nil, err
+	}
+	if len(*l) == 0 {
+		return nil, nil
+	}
+	return &(*l)[0], nil
+}
+
+// InsertUser inserts the tuple into the "users" space and returns the new
+// tuple.
+func InsertUser(ctx context.Context, doer tarantool.Doer, tuple *User) (*User, error) {
+	req := tarantool.NewInsertRequest(UsersSpace).Tuple(tuple).Context(ctx)
+	var tuples userList
+	return tuples.first(doer.Do(req))
+}
+
+// ReplaceUser inserts or replaces the tuple into the "users" space and
+// returns the new tuple.
+func ReplaceUser(ctx context.Context, doer tarantool.Doer, tuple *User) (*User, error) {
+	req := tarantool.NewReplaceRequest(UsersSpace).Tuple(tuple).Context(ctx)
+	var tuples userList
+	return tuples.first(doer.Do(req))
+}
+
+// GetUserByPrimary returns a tuple of the "users" space by the "primary"
+// index or nil if the tuple is not found.
+func GetUserByPrimary(ctx context.Context, doer tarantool.Doer, id uint64) (*User, error) {
+	req := tarantool.NewSelectRequest(UsersSpace).
+		Index("primary").
+		Iterator(tarantool.IterEq).
+		Limit(1).
+		Key([]interface{}{id}).
+		Context(ctx)
+	var tuples userList
+	return tuples.first(doer.Do(req))
+}
+
+// DeleteUserByPrimary deletes a tuple of the "users" space by the "primary"
+// index and returns the deleted tuple or nil if the tuple is not found.
+func DeleteUserByPrimary(ctx context.Context, doer tarantool.Doer, id uint64) (*User, error) {
+	req := tarantool.NewDeleteRequest(UsersSpace).
+		Index("primary").
+		Key([]interface{}{id}).
+		Context(ctx)
+	var tuples userList
+	return tuples.first(doer.Do(req))
+}
+
+// GetUserByEmail returns a tuple of the "users" space by the "email" index
+// or nil if the tuple is not found.
+func GetUserByEmail(ctx context.Context, doer tarantool.Doer, email string) (*User, error) {
+	req := tarantool.NewSelectRequest(UsersSpace).
+		Index("email").
+		Iterator(tarantool.IterEq).
+		Limit(1).
+		Key([]interface{}{email}).
+		Context(ctx)
+	var tuples userList
+	return tuples.first(doer.Do(req))
+}
+
+// DeleteUserByEmail deletes a tuple of the "users" space by the "email"
+// index and returns the deleted tuple or nil if the tuple is not found.
+func DeleteUserByEmail(ctx context.Context, doer tarantool.Doer, email string) (*User, error) {
+	req := tarantool.NewDeleteRequest(UsersSpace).
+		Index("email").
+		Key([]interface{}{email}).
+		Context(ctx)
+	var tuples userList
+	return tuples.first(doer.Do(req))
+}
+
+// SelectUsersByName returns tuples of the "users" space by the "name" index.
+func SelectUsersByName(ctx context.Context, doer tarantool.Doer, name string) ([]User, error) {
+	req := tarantool.NewSelectRequest(UsersSpace).
+		Index("name").
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{name}).
+		Context(ctx)
+	var tuples userList
+	err := doer.Do(req).GetTyped(&tuples)
+	return tuples, err
+}
+
+// decodeNullable decodes a nullable value with the decode function.
+func decodeNullable[T any](dec *msgpack.Decoder, decode func() (T, error)) (*T, error) {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return nil, err
+	}
+	if code == msgpcode.Nil {
+		return nil, dec.DecodeNil()
+	}
+
+	v, err := decode()
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,130 @@
+package example_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/cmd/tarantool-gen/internal/example"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+// handleUsers sets handlers that store tuples of the "users" space in
+// memory.
+func handleUsers(server *mockserver.Server) {
+	var mutex sync.Mutex
+	var users [][]interface{}
+	find := func(req *mockserver.Request) []int {
+		field := map[string]int{"primary": 0, "email": 1, "name": 2}[req.IndexName()]
+		var found []int
+		for i, user := range users {
+			if fmt.Sprint(user[field]) == fmt.Sprint(req.Key()[0]) {
+				found = append(found, i)
+			}
+		}
+		return found
+	}
+
+	server.Handle(iproto.IPROTO_INSERT, func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		users = append(users, req.Tuple())
+		return []interface{}{req.Tuple()}, nil
+	})
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		found := find(req)
+		data := []interface{}{}
+		for _, i := range found {
+			data = append(data, users[i])
+		}
+		return data, nil
+	})
+	server.Handle(iproto.IPROTO_DELETE, func(req *mockserver.Request) ([]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		found := find(req)
+		if len(found) == 0 {
+			return []interface{}{}, nil
+		}
+		user := users[found[0]]
+		users = append(users[:found[0]], users[found[0]+1:]...)
+		return []interface{}{user}, nil
+	})
+}
+
+func TestGenerated(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleUsers(server)
+	conn := server.Connect(t, tarantool.Opts{})
+	ctx := context.Background()
+
+	age := uint64(30)
+	alice := &example.User{
+		Id:    1,
+		Email: "alice@example.com",
+		Name:  "Alice",
+		Age:   &age,
+		Tags:  []interface{}{"admin"},
+		Settings: map[interface{}]interface{}{
+			"theme": "dark",
+		},
+	}
+	bob := &example.User{Id: 2, Email: "bob@example.com", Name: "Bob"}
+	alice2 := &example.User{Id: 3, Email: "alice2@example.com", Name: "Alice"}
+
+	for _, user := range []*example.User{alice, bob, alice2} {
+		inserted, err := example.InsertUser(ctx, conn, user)
+		require.NoError(t, err)
+		assert.Equal(t, user, inserted)
+	}
+
+	user, err := example.GetUserByEmail(ctx, conn, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, alice, user)
+
+	user, err = example.GetUserByPrimary(ctx, conn, 2)
+	require.NoError(t, err)
+	assert.Equal(t, bob, user)
+
+	users, err := example.SelectUsersByName(ctx, conn, "Alice")
+	require.NoError(t, err)
+	assert.Equal(t, []example.User{*alice, *alice2}, users)
+
+	user, err = example.DeleteUserByPrimary(ctx, conn, 2)
+	require.NoError(t, err)
+	assert.Equal(t, bob, user)
+
+	user, err = example.GetUserByEmail(ctx, conn, "bob@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	users, err = example.SelectUsersByName(ctx, conn, "Bob")
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestGenerated_decodeError(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleUsers(server)
+	conn := server.Connect(t, tarantool.Opts{})
+	ctx := context.Background()
+
+	req := tarantool.NewInsertRequest(example.UsersSpace).
+		Tuple([]interface{}{1, "alice@example.com", 42})
+	_, err := conn.Do(req).Get()
+	require.NoError(t, err)
+
+	_, err = example.GetUserByPrimary(ctx, conn, 1)
+	assert.ErrorContains(t, err, `users: failed to decode field "name"`)
+}
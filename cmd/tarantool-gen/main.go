@@ -0,0 +1,133 @@
+// Command tarantool-gen generates Go code for spaces of a Tarantool schema.
+//
+// The schema is loaded from a running instance or from a file saved with
+// the -save-schema flag:
+//
+//	tarantool-gen -addr 127.0.0.1:3301 -user admin -password secret \
+//		-package model -out model_gen.go -save-schema schema.json
+//	tarantool-gen -schema schema.json -package model -out model_gen.go
+//
+// For every user space with a format the command generates:
+//
+//   - a constant with the space name;
+//   - a struct with a field for every field of the format and
+//     reflection-free EncodeMsgpack and DecodeMsgpack methods;
+//   - Insert<Type>() and Replace<Type>() functions;
+//   - Get<Type>By<Index>() and Delete<Type>By<Index>() functions for every
+//     unique TREE or HASH index;
+//   - Select<Plural>By<Index>() functions for every non-unique TREE or HASH
+//     index.
+//
+// A type name is a singular form of the space name, for example, the
+// "users" space with the "email" unique index gets the User type and the
+// GetUserByEmail(ctx, doer, email) function. The functions accept any
+// tarantool.Doer: a connection, a stream or a pool wrapped with
+// pool.NewConnectorAdapter().
+//
+// Nullable fields of scalar types are represented with pointers. Fields of
+// "array", "map", "varbinary" types are represented with Go slices and maps,
+// fields of other types are represented with interface{}.
+//
+// The generated code should be placed in a package as a single file, it
+// declares helpers that are not exported.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "tarantool-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("tarantool-gen", flag.ContinueOnError)
+	addr := flags.String("addr", "", "address of a Tarantool instance")
+	user := flags.String("user", "guest", "user name")
+	password := flags.String("password", "", "user password")
+	timeout := flags.Duration("timeout", 10*time.Second, "connect timeout")
+	schemaPath := flags.String("schema", "", "load a schema from the file")
+	savePath := flags.String("save-schema", "",
+		"save a schema loaded from the instance into the file")
+	pkg := flags.String("package", "model", "package name of the generated code")
+	out := flags.String("out", "", "output file, stdout by default")
+	spaces := flags.String("spaces", "",
+		"comma-separated list of spaces, all user spaces by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var schema tarantool.Schema
+	var err error
+	switch {
+	case *addr != "" && *schemaPath != "":
+		return errors.New("-addr and -schema could not be used together")
+	case *addr != "":
+		dialer := tarantool.NetDialer{
+			Address:  *addr,
+			User:     *user,
+			Password: *password,
+		}
+		if schema, err = loadSchema(dialer, *timeout); err != nil {
+			return err
+		}
+		if *savePath != "" {
+			if err := saveSchemaDump(*savePath, schema); err != nil {
+				return fmt.Errorf("failed to save schema: %w", err)
+			}
+		}
+	case *schemaPath != "":
+		if schema, err = loadSchemaDump(*schemaPath); err != nil {
+			return err
+		}
+	default:
+		return errors.New("-addr or -schema should be specified")
+	}
+
+	opts := genOpts{Package: *pkg}
+	if *spaces != "" {
+		opts.Spaces = strings.Split(*spaces, ",")
+	}
+	code, err := generate(schema, opts)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(*out, code, 0644)
+}
+
+// loadSchema loads a schema from a Tarantool instance.
+func loadSchema(dialer tarantool.Dialer, timeout time.Duration) (tarantool.Schema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := tarantool.Connect(ctx, dialer, tarantool.Opts{
+		Timeout:    timeout,
+		SkipSchema: true,
+	})
+	if err != nil {
+		return tarantool.Schema{}, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	schema, err := tarantool.GetSchema(conn)
+	if err != nil {
+		return tarantool.Schema{}, fmt.Errorf("failed to get schema: %w", err)
+	}
+	return schema, nil
+}
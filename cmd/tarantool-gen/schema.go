@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// schemaDump is a saved schema. It contains only spaces with a format.
+type schemaDump struct {
+	Spaces []spaceDump `json:"spaces"`
+}
+
+type spaceDump struct {
+	Id      uint32      `json:"id"`
+	Name    string      `json:"name"`
+	Engine  string      `json:"engine,omitempty"`
+	Fields  []fieldDump `json:"fields"`
+	Indexes []indexDump `json:"indexes"`
+}
+
+type fieldDump struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	IsNullable bool   `json:"is_nullable,omitempty"`
+}
+
+type indexDump struct {
+	Id     uint32     `json:"id"`
+	Name   string     `json:"name"`
+	Type   string     `json:"type"`
+	Unique bool       `json:"unique"`
+	Parts  []partDump `json:"parts"`
+}
+
+type partDump struct {
+	Field uint32 `json:"field"`
+	Type  string `json:"type"`
+}
+
+// newSchemaDump creates a dump of user spaces of the schema.
+func newSchemaDump(schema tarantool.Schema) schemaDump {
+	var dump schemaDump
+	for _, space := range userSpaces(schema) {
+		spaceDump := spaceDump{
+			Id:     space.Id,
+			Name:   space.Name,
+			Engine: space.Engine,
+		}
+		for _, field := range spaceFields(space) {
+			spaceDump.Fields = append(spaceDump.Fields, fieldDump{
+				Name:       field.Name,
+				Type:       field.Type,
+				IsNullable: field.IsNullable,
+			})
+		}
+		for _, index := range spaceIndexes(space) {
+			indexDump := indexDump{
+				Id:     index.Id,
+				Name:   index.Name,
+				Type:   index.Type,
+				Unique: index.Unique,
+			}
+			for _, part := range index.Fields {
+				indexDump.Parts = append(indexDump.Parts, partDump{
+					Field: part.Id,
+					Type:  part.Type,
+				})
+			}
+			spaceDump.Indexes = append(spaceDump.Indexes, indexDump)
+		}
+		dump.Spaces = append(dump.Spaces, spaceDump)
+	}
+	return dump
+}
+
+// schema converts the dump into a schema.
+func (dump schemaDump) schema() tarantool.Schema {
+	schema := tarantool.Schema{
+		Spaces:     make(map[string]tarantool.Space),
+		SpacesById: make(map[uint32]tarantool.Space),
+	}
+	for _, spaceDump := range dump.Spaces {
+		space := tarantool.Space{
+			Id:          spaceDump.Id,
+			Name:        spaceDump.Name,
+			Engine:      spaceDump.Engine,
+			FieldsCount: uint32(len(spaceDump.Fields)),
+			Fields:      make(map[string]tarantool.Field),
+			FieldsById:  make(map[uint32]tarantool.Field),
+			Indexes:     make(map[string]tarantool.Index),
+			IndexesById: make(map[uint32]tarantool.Index),
+		}
+		for i, fieldDump := range spaceDump.Fields {
+			field := tarantool.Field{
+				Id:         uint32(i),
+				Name:       fieldDump.Name,
+				Type:       fieldDump.Type,
+				IsNullable: fieldDump.IsNullable,
+			}
+			space.Fields[field.Name] = field
+			space.FieldsById[field.Id] = field
+		}
+		for _, indexDump := range spaceDump.Indexes {
+			index := tarantool.Index{
+				Id:      indexDump.Id,
+				SpaceId: space.Id,
+				Name:    indexDump.Name,
+				Type:    indexDump.Type,
+				Unique:  indexDump.Unique,
+			}
+			for _, part := range indexDump.Parts {
+				index.Fields = append(index.Fields, tarantool.IndexField{
+					Id:   part.Field,
+					Type: part.Type,
+				})
+			}
+			space.Indexes[index.Name] = index
+			space.IndexesById[index.Id] = index
+		}
+		schema.Spaces[space.Name] = space
+		schema.SpacesById[space.Id] = space
+	}
+	return schema
+}
+
+// loadSchemaDump reads a schema from a file created with saveSchemaDump.
+func loadSchemaDump(path string) (tarantool.Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tarantool.Schema{}, err
+	}
+
+	var dump schemaDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return tarantool.Schema{}, fmt.Errorf("failed to parse schema %s: %w", path, err)
+	}
+	return dump.schema(), nil
+}
+
+// saveSchemaDump writes user spaces of a schema into a file.
+func saveSchemaDump(path string, schema tarantool.Schema) error {
+	data, err := json.MarshalIndent(newSchemaDump(schema), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// userSpaces returns spaces with a format sorted by names. System spaces
+// are skipped.
+func userSpaces(schema tarantool.Schema) []tarantool.Space {
+	var spaces []tarantool.Space
+	for _, space := range schema.SpacesById {
+		if space.Name == "" || space.Name[0] == '_' || len(space.FieldsById) == 0 {
+			continue
+		}
+		spaces = append(spaces, space)
+	}
+	sort.Slice(spaces, func(i, j int) bool {
+		return spaces[i].Name < spaces[j].Name
+	})
+	return spaces
+}
+
+// spaceFields returns fields of a space sorted by ids.
+func spaceFields(space tarantool.Space) []tarantool.Field {
+	fields := make([]tarantool.Field, 0, len(space.FieldsById))
+	for _, field := range space.FieldsById {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Id < fields[j].Id
+	})
+	return fields
+}
+
+// spaceIndexes returns indexes of a space sorted by ids.
+func spaceIndexes(space tarantool.Space) []tarantool.Index {
+	indexes := make([]tarantool.Index, 0, len(space.IndexesById))
+	for _, index := range space.IndexesById {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Id < indexes[j].Id
+	})
+	return indexes
+}
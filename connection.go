@@ -63,6 +63,9 @@ const (
 	LogWatchEventReadFailed
 	// LogAppendPushFailed is logged when failed to append a push response.
 	LogAppendPushFailed
+	// LogSchemaReloadFailed is logged when failed to reload a schema after
+	// the schema version has been changed.
+	LogSchemaReloadFailed
 )
 
 // ConnEvent is sent throw Notify channel specified in Opts.
@@ -109,6 +112,9 @@ func (d defaultLogger) Report(event ConnLogKind, conn *Connection, v ...interfac
 	case LogAppendPushFailed:
 		err := v[0].(error)
 		log.Printf("tarantool: unable to append a push response: %s", err)
+	case LogSchemaReloadFailed:
+		err := v[0].(error)
+		log.Printf("tarantool: unable to reload a schema of %s: %s", conn.Addr(), err)
 	default:
 		args := append([]interface{}{"tarantool: unexpected event ", event, conn}, v...)
 		log.Print(args...)
@@ -182,6 +188,10 @@ type Connection struct {
 
 	control chan struct{}
 	rlimit  chan struct{}
+	// limiter adjusts the rlimit capacity if AdaptiveLimit is set.
+	limiter *adaptiveLimiter
+	// breaker fails requests fast if CircuitBreaker is set.
+	breaker *circuitBreaker
 	opts    Opts
 	state   uint32
 	dec     *msgpack.Decoder
@@ -197,6 +207,14 @@ type Connection struct {
 	shutdownWatcher Watcher
 	// requestCnt is a counter of active requests.
 	requestCnt int64
+
+	// schemaMutex serializes schema reloads.
+	schemaMutex sync.Mutex
+	// schemaVersion is a version of the loaded schema.
+	schemaVersion uint64
+	// serverSchemaVersion is the latest schema version received from the
+	// server.
+	serverSchemaVersion uint64
 }
 
 var _ = Connector(&Connection{}) // Check compatibility with connector interface.
@@ -251,6 +269,8 @@ type connShard struct {
 	bufmut          sync.Mutex
 	buf             smallWBuf
 	enc             *msgpack.Encoder
+	// versioned is reused by pack() under bufmut.
+	versioned versionedResolver
 }
 
 // RLimitActions is an enumeration type for an action to do when a rate limit
@@ -296,8 +316,11 @@ type Opts struct {
 	// See RLimitAction for possible actions when RateLimit.reached.
 	RateLimit uint
 	// RLimitAction tells what to do when RateLimit is reached.
-	// It is required if RateLimit is specified.
+	// It is required if RateLimit or AdaptiveLimit is specified.
 	RLimitAction RLimitAction
+	// AdaptiveLimit enables an adaptive limit of 'in-fly' requests instead
+	// of the static RateLimit. It is disabled by default.
+	AdaptiveLimit *AdaptiveLimit
 	// Concurrency is amount of separate mutexes for request
 	// queues and buffers inside of connection.
 	// It is rounded up to nearest power of 2.
@@ -305,6 +328,11 @@ type Opts struct {
 	Concurrency uint32
 	// SkipSchema disables schema loading. Without disabling schema loading,
 	// there is no way to create Connection for currently not accessible Tarantool.
+	//
+	// If the schema is loaded, it is reloaded in the background when a
+	// response with a new schema version is received. A request with names
+	// resolved by the schema is sent with the schema version and it is sent
+	// again once if Tarantool rejects it due to a stale schema.
 	SkipSchema bool
 	// Notify is a channel which receives notifications about Connection status
 	// changes.
@@ -314,6 +342,28 @@ type Opts struct {
 	Handle interface{}
 	// Logger is user specified logger used for error messages.
 	Logger Logger
+	// Metrics is used to collect metrics of the connection. It is disabled
+	// by default.
+	Metrics Metrics
+	// Tracer is used to trace requests of the connection. It is disabled by
+	// default.
+	Tracer Tracer
+	// Interceptors intercept requests passed to Connection.Do() and
+	// Stream.Do(), including requests sent by the connection itself. The
+	// first interceptor is the outermost one. An interceptor must not call
+	// Do() of the connection or the stream, use the next Doer instead.
+	Interceptors []Interceptor
+	// RetryPolicy is used to retry failed idempotent requests passed to
+	// Connection.Do(). Interceptors are called once for a request, before
+	// retries. Requests of streams are not retried. Retries are disabled by
+	// default.
+	RetryPolicy *RetryPolicy
+	// CircuitBreaker makes requests fail fast with ErrCircuitOpen while
+	// the instance is unhealthy. It is disabled by default.
+	CircuitBreaker *CircuitBreaker
+	// OnConnect is called on every successful connection and reconnection
+	// to restore a state of a session. See OnConnectFunc.
+	OnConnect OnConnectFunc
 }
 
 // Connect creates and configures a new Connection.
@@ -349,8 +399,25 @@ func Connect(ctx context.Context, dialer Dialer, opts Opts) (conn *Connection, e
 		}
 	}
 
+	if conn.opts.RateLimit > 0 && conn.opts.AdaptiveLimit != nil {
+		return nil, errors.New("RateLimit and AdaptiveLimit could not be used together")
+	}
 	if conn.opts.RateLimit > 0 {
 		conn.rlimit = make(chan struct{}, conn.opts.RateLimit)
+	}
+	if conn.opts.AdaptiveLimit != nil {
+		if conn.limiter, err = newAdaptiveLimiter(*conn.opts.AdaptiveLimit); err != nil {
+			return nil, err
+		}
+		conn.rlimit = conn.limiter.rlimit
+	}
+	if conn.opts.CircuitBreaker != nil {
+		conn.breaker, err = newCircuitBreaker(conn, *conn.opts.CircuitBreaker)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if conn.rlimit != nil {
 		if conn.opts.RLimitAction != RLimitDrop && conn.opts.RLimitAction != RLimitWait {
 			return nil, errors.New("RLimitAction should be specified to RLimitDone nor RLimitWait")
 		}
@@ -373,7 +440,7 @@ func Connect(ctx context.Context, dialer Dialer, opts Opts) (conn *Connection, e
 
 	// TODO: reload schema after reconnect.
 	if !conn.opts.SkipSchema {
-		schema, err := GetSchema(conn)
+		schema, err := GetSchema(DoerFunc(conn.do))
 		if err != nil {
 			conn.mutex.Lock()
 			defer conn.mutex.Unlock()
@@ -423,7 +490,8 @@ func (conn *Connection) Handle() interface{} {
 }
 
 func (conn *Connection) cancelFuture(fut *Future, err error) {
-	if fut = conn.fetchFuture(fut.requestId); fut != nil {
+	// The request ID is changed if the request is sent again.
+	if fut = conn.fetchFuture(atomic.LoadUint32(&fut.requestId)); fut != nil {
 		fut.SetError(err)
 		conn.markDone(fut)
 	}
@@ -455,6 +523,14 @@ func (conn *Connection) dial(ctx context.Context) error {
 		}
 	}
 
+	if opts.OnConnect != nil {
+		doer := onConnectDoer{c: c, resolver: conn.schemaResolver}
+		if err = opts.OnConnect(ctx, doer); err != nil {
+			c.Close()
+			return fmt.Errorf("on connect failed: %w", err)
+		}
+	}
+
 	// Watchers.
 	conn.watchMap.Range(func(key, value interface{}) bool {
 		st := value.(chan watchState)
@@ -503,8 +579,14 @@ func (conn *Connection) dial(ctx context.Context) error {
 	return nil
 }
 
-func pack(h *smallWBuf, enc *msgpack.Encoder, reqid uint32,
-	req Request, streamId uint64, res SchemaResolver) (err error) {
+// pack encodes the request into the buffer. It returns a schema version
+// sent with the request or zero if the version is not sent.
+//
+// The versioned resolver, if any, is reused to track names resolved by a
+// loaded schema.
+func pack(h *smallWBuf, enc *msgpack.Encoder, reqid uint32, req Request,
+	streamId uint64, res SchemaResolver,
+	versioned *versionedResolver) (schemaVersion uint64, err error) {
 	const uint32Code = 0xce
 	const uint64Code = 0xcf
 	const streamBytesLenUint64 = 10
@@ -529,6 +611,17 @@ func pack(h *smallWBuf, enc *msgpack.Encoder, reqid uint32,
 		}
 	}
 
+	// A request with names resolved by a loaded schema is sent with the
+	// schema version, so Tarantool could reject it if the schema is stale.
+	if loaded, ok := res.(*loadedSchemaResolver); ok &&
+		versioned != nil && loaded.Schema.Version != 0 {
+		*versioned = versionedResolver{loadedSchemaResolver: loaded}
+		defer func() {
+			*versioned = versionedResolver{}
+		}()
+		res = versioned
+	}
+
 	hBytes := append([]byte{
 		uint32Code, 0, 0, 0, 0, // Length.
 		hMapLen,
@@ -539,11 +632,27 @@ func pack(h *smallWBuf, enc *msgpack.Encoder, reqid uint32,
 	}, streamBytes[:streamBytesLen]...)
 
 	h.Write(hBytes)
+	bodyPos := h.Len()
 
 	if err = req.Body(res, enc); err != nil {
 		return
 	}
 
+	// The body is encoded after the header, so the version is inserted
+	// between them once it is known that names have been resolved.
+	if versioned != nil && versioned.used {
+		schemaVersion = uint64(versioned.Schema.Version)
+		var versionBytes [10]byte
+		versionBytes[0] = byte(iproto.IPROTO_SCHEMA_VERSION)
+		versionBytes[1] = uint64Code
+		binary.BigEndian.PutUint64(versionBytes[2:], schemaVersion)
+
+		h.Write(versionBytes[:])
+		copy(h.b[bodyPos+len(versionBytes):], h.b[bodyPos:])
+		copy(h.b[bodyPos:], versionBytes[:])
+		h.b[hl+5]++ // The header map length.
+	}
+
 	l := uint32(h.Len() - 5 - hl)
 	h.b[hl+1] = byte(l >> 24)
 	h.b[hl+2] = byte(l >> 16)
@@ -557,6 +666,9 @@ func (conn *Connection) createConnection(ctx context.Context) error {
 	var err error
 	if conn.c == nil && conn.state == connDisconnected {
 		if err = conn.dial(ctx); err == nil {
+			if conn.breaker != nil {
+				conn.breaker.connected()
+			}
 			conn.notify(Connected)
 			return nil
 		}
@@ -704,6 +816,9 @@ func (conn *Connection) pinger() {
 }
 
 func (conn *Connection) notify(kind ConnEventKind) {
+	if conn.opts.Metrics != nil {
+		conn.opts.Metrics.ConnectionEvent(conn, kind)
+	}
 	if conn.opts.Notify != nil {
 		select {
 		case conn.opts.Notify <- ConnEvent{Kind: kind, Conn: conn, When: time.Now()}:
@@ -722,6 +837,7 @@ func (conn *Connection) writer(w writeFlusher, c Conn) {
 			runtime.Gosched()
 			if len(conn.dirtyShard) == 0 {
 				if err := w.Flush(); err != nil {
+					conn.ioFailed(err)
 					err = ClientError{
 						ErrIoError,
 						fmt.Sprintf("failed to flush data to the connection: %s", err),
@@ -749,6 +865,7 @@ func (conn *Connection) writer(w writeFlusher, c Conn) {
 			continue
 		}
 		if _, err := w.Write(packet.b); err != nil {
+			conn.ioFailed(err)
 			err = ClientError{
 				ErrIoError,
 				fmt.Sprintf("failed to write data to the connection: %s", err),
@@ -756,6 +873,9 @@ func (conn *Connection) writer(w writeFlusher, c Conn) {
 			conn.reconnect(err, c)
 			return
 		}
+		if conn.opts.Metrics != nil {
+			conn.opts.Metrics.BytesWritten(conn, packet.Len())
+		}
 		packet.Reset()
 	}
 }
@@ -809,6 +929,7 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 	for atomic.LoadUint32(&conn.state) != connClosed {
 		respBytes, err := read(r, conn.lenbuf[:])
 		if err != nil {
+			conn.ioFailed(err)
 			err = ClientError{
 				ErrIoError,
 				fmt.Sprintf("failed to read data from the connection: %s", err),
@@ -816,6 +937,9 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 			conn.reconnect(err, c)
 			return
 		}
+		if conn.opts.Metrics != nil {
+			conn.opts.Metrics.BytesRead(conn, len(respBytes)+packetLengthBytes)
+		}
 		buf := smallBuf{b: respBytes}
 		header, code, err := decodeHeader(conn.dec, &buf)
 		if err != nil {
@@ -826,6 +950,7 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 			conn.reconnect(err, c)
 			return
 		}
+		conn.checkSchemaVersion(header.SchemaVersion)
 
 		var fut *Future = nil
 		if code == iproto.IPROTO_EVENT {
@@ -851,6 +976,10 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 			}
 		} else {
 			if fut = conn.fetchFuture(header.RequestId); fut != nil {
+				if fut.retrySchema(header) {
+					go conn.resendWithSchema(fut, header.SchemaVersion)
+					continue
+				}
 				if err := fut.SetResponse(header, &buf); err != nil {
 					fut.SetError(fmt.Errorf("failed to set response: %w", err))
 				}
@@ -889,9 +1018,22 @@ func (conn *Connection) eventer(events <-chan connWatchEvent) {
 	}
 }
 
-func (conn *Connection) newFuture(req Request) (fut *Future) {
+// newFuture creates a future for the request and registers it. The
+// beforeWait function, if any, is called before the future waits for the
+// rate limit with RLimitWait.
+func (conn *Connection) newFuture(req Request, beforeWait func()) (fut *Future) {
 	ctx := req.Ctx()
 	fut = NewFuture(req)
+	conn.requestStarted(fut)
+	if conn.breaker != nil && !conn.breaker.allow(req) {
+		fut.err = ClientError{
+			ErrCircuitOpen,
+			"circuit breaker is open",
+		}
+		fut.ready = nil
+		fut.done = nil
+		return
+	}
 	if conn.rlimit != nil && conn.opts.RLimitAction == RLimitDrop {
 		select {
 		case conn.rlimit <- struct{}{}:
@@ -942,7 +1084,9 @@ func (conn *Connection) newFuture(req Request) (fut *Future) {
 	if ctx != nil {
 		select {
 		case <-ctx.Done():
-			fut.SetError(fmt.Errorf("context is done (request ID %d)", fut.requestId))
+			fut.err = fmt.Errorf("context is done (request ID %d)", fut.requestId)
+			fut.ready = nil
+			fut.done = nil
 			shard.rmut.Unlock()
 			return
 		default:
@@ -959,13 +1103,13 @@ func (conn *Connection) newFuture(req Request) (fut *Future) {
 		select {
 		case conn.rlimit <- struct{}{}:
 		default:
+			if beforeWait != nil {
+				beforeWait()
+			}
 			runtime.Gosched()
 			select {
 			case conn.rlimit <- struct{}{}:
 			case <-fut.done:
-				if fut.err == nil {
-					panic("fut.done is closed, but err is nil")
-				}
 			}
 		}
 	}
@@ -984,7 +1128,8 @@ func (conn *Connection) contextWatchdog(fut *Future, ctx context.Context) {
 	case <-fut.done:
 		return
 	default:
-		conn.cancelFuture(fut, fmt.Errorf("context is done (request ID %d)", fut.requestId))
+		conn.cancelFuture(fut, fmt.Errorf("context is done (request ID %d)",
+			atomic.LoadUint32(&fut.requestId)))
 	}
 }
 
@@ -999,26 +1144,37 @@ func (conn *Connection) decrementRequestCnt() {
 }
 
 func (conn *Connection) send(req Request, streamId uint64) *Future {
+	fut, ok := conn.startFuture(req, nil)
+	if ok {
+		conn.putFuture(fut, req, streamId)
+	}
+	return fut
+}
+
+// startFuture creates a future for the request and registers it. It returns
+// false if the future is already done and the request must not be sent. The
+// beforeWait function is passed to newFuture().
+func (conn *Connection) startFuture(req Request, beforeWait func()) (*Future, bool) {
 	conn.incrementRequestCnt()
 
-	fut := conn.newFuture(req)
+	fut := conn.newFuture(req, beforeWait)
+	conn.startTrace(fut)
 	if fut.ready == nil {
+		conn.requestDone(fut)
 		conn.decrementRequestCnt()
-		return fut
+		return fut, false
 	}
 
 	if req.Ctx() != nil {
 		select {
 		case <-req.Ctx().Done():
 			conn.cancelFuture(fut, fmt.Errorf("context is done (request ID %d)", fut.requestId))
-			return fut
+			return fut, false
 		default:
 		}
 		go conn.contextWatchdog(fut, req.Ctx())
 	}
-	conn.putFuture(fut, req, streamId)
-
-	return fut
+	return fut, true
 }
 
 func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
@@ -1038,28 +1194,13 @@ func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
 	}
 	blen := shard.buf.Len()
 	reqid := fut.requestId
-	if err := pack(&shard.buf, shard.enc, reqid, req, streamId, conn.schemaResolver); err != nil {
+	schemaVersion, err := pack(&shard.buf, shard.enc, reqid, req, streamId,
+		conn.schemaResolver, &shard.versioned)
+	fut.setSent(streamId, schemaVersion)
+	if err != nil {
 		shard.buf.Trunc(blen)
 		shard.bufmut.Unlock()
-		if f := conn.fetchFuture(reqid); f == fut {
-			fut.SetError(err)
-			conn.markDone(fut)
-		} else if f != nil {
-			/* in theory, it is possible. In practice, you have
-			 * to have race condition that lasts hours */
-			panic("Unknown future")
-		} else {
-			fut.wait()
-			if fut.err == nil {
-				panic("Future removed from queue without error")
-			}
-			if _, ok := fut.err.(ClientError); ok {
-				// packing error is more important than connection
-				// error, because it is indication of programmer's
-				// mistake.
-				fut.SetError(err)
-			}
-		}
+		conn.packFailed(fut, err)
 		return
 	}
 	shard.bufmut.Unlock()
@@ -1069,20 +1210,56 @@ func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
 	}
 
 	if req.Async() {
-		if fut = conn.fetchFuture(reqid); fut != nil {
-			header := Header{
-				RequestId: reqid,
-				Error:     ErrorNo,
-			}
-			fut.SetResponse(header, nil)
-			conn.markDone(fut)
+		conn.asyncSent(reqid)
+	}
+}
+
+// packFailed completes the future with a packing error.
+func (conn *Connection) packFailed(fut *Future, err error) {
+	if f := conn.fetchFuture(fut.requestId); f == fut {
+		fut.SetError(err)
+		conn.markDone(fut)
+	} else if f != nil {
+		/* in theory, it is possible. In practice, you have
+		 * to have race condition that lasts hours */
+		panic("Unknown future")
+	} else {
+		fut.wait()
+		if fut.err == nil {
+			panic("Future removed from queue without error")
+		}
+		if _, ok := fut.err.(ClientError); ok {
+			// packing error is more important than connection
+			// error, because it is indication of programmer's
+			// mistake.
+			fut.SetError(err)
+		}
+	}
+}
+
+// asyncSent completes a future of an async request after it was sent.
+func (conn *Connection) asyncSent(reqid uint32) {
+	if fut := conn.fetchFuture(reqid); fut != nil {
+		header := Header{
+			RequestId: reqid,
+			Error:     ErrorNo,
 		}
+		fut.SetResponse(header, nil)
+		conn.markDone(fut)
 	}
 }
 
 func (conn *Connection) markDone(fut *Future) {
+	conn.requestDone(fut)
+	conn.finishTrace(fut)
 	if conn.rlimit != nil {
 		<-conn.rlimit
+		if conn.limiter != nil {
+			conn.limiter.sample(time.Since(fut.start), fut.err)
+		}
+	}
+	if conn.breaker != nil {
+		conn.breaker.sample(fut.err)
 	}
 	conn.decrementRequestCnt()
 }
@@ -1218,6 +1395,20 @@ func (conn *Connection) nextRequestId(context bool) (requestId uint32) {
 // An error is returned if the request was formed incorrectly, or failed to
 // create the future.
 func (conn *Connection) Do(req Request) *Future {
+	last := DoerFunc(conn.do)
+	if policy := conn.opts.RetryPolicy; policy != nil {
+		last = func(req Request) *Future {
+			return policy.Intercept(req, DoerFunc(conn.do))
+		}
+	}
+	if len(conn.opts.Interceptors) != 0 {
+		return chainDoer(conn.opts.Interceptors, last).Do(req)
+	}
+	return last(req)
+}
+
+// do performs a request without interceptors.
+func (conn *Connection) do(req Request) *Future {
 	if connectedReq, ok := req.(ConnectedRequest); ok {
 		if connectedReq.Conn() != conn {
 			fut := NewFuture(req)
@@ -1228,6 +1419,25 @@ func (conn *Connection) Do(req Request) *Future {
 	return conn.send(req, ignoreStreamId)
 }
 
+// RateLimit returns the current limit of 'in-fly' requests: the adaptive
+// limit if Opts.AdaptiveLimit is set or Opts.RateLimit otherwise. Zero means
+// no limit.
+func (conn *Connection) RateLimit() uint {
+	if conn.limiter != nil {
+		return conn.limiter.Limit()
+	}
+	return conn.opts.RateLimit
+}
+
+// CircuitState returns the current state of the circuit breaker. It is
+// always CircuitClosed if Opts.CircuitBreaker is not set.
+func (conn *Connection) CircuitState() CircuitState {
+	if conn.breaker != nil {
+		return conn.breaker.State()
+	}
+	return CircuitClosed
+}
+
 // ConfiguredTimeout returns a timeout from connection config.
 func (conn *Connection) ConfiguredTimeout() time.Duration {
 	return conn.opts.Timeout
@@ -1249,6 +1459,93 @@ func (conn *Connection) SetSchema(s Schema) {
 		Schema:                      sCopy,
 		SpaceAndIndexNamesSupported: spaceAndIndexNamesSupported,
 	}
+	atomic.StoreUint64(&conn.schemaVersion, uint64(sCopy.Version))
+}
+
+// checkSchemaVersion starts a schema reload in the background if the
+// schema version received from the server differs from the loaded one.
+func (conn *Connection) checkSchemaVersion(version uint64) {
+	if version == 0 || atomic.SwapUint64(&conn.serverSchemaVersion, version) == version {
+		return
+	}
+
+	// The schema is not loaded yet or it is managed by a user.
+	loaded := atomic.LoadUint64(&conn.schemaVersion)
+	if conn.opts.SkipSchema || loaded == 0 || loaded == version {
+		return
+	}
+	go func() {
+		if err := conn.reloadSchema(version); err != nil {
+			// Forget the version, so a next response retries the reload.
+			atomic.CompareAndSwapUint64(&conn.serverSchemaVersion, version, 0)
+			conn.opts.Logger.Report(LogSchemaReloadFailed, conn, err)
+		}
+	}()
+}
+
+// reloadSchema loads the schema if the loaded one has another version.
+func (conn *Connection) reloadSchema(version uint64) error {
+	conn.schemaMutex.Lock()
+	defer conn.schemaMutex.Unlock()
+
+	if atomic.LoadUint64(&conn.schemaVersion) == version {
+		return nil
+	}
+	// The internal requests bypass interceptors and the retry policy.
+	schema, err := GetSchema(DoerFunc(conn.do))
+	if err != nil {
+		return err
+	}
+	conn.SetSchema(schema)
+	return nil
+}
+
+// resendWithSchema reloads the schema and sends the request again after it
+// has been rejected due to a stale schema.
+func (conn *Connection) resendWithSchema(fut *Future, version uint64) {
+	if err := conn.reloadSchema(version); err != nil {
+		fut.SetError(fmt.Errorf("failed to reload schema: %w", err))
+		conn.markDone(fut)
+		return
+	}
+
+	ctx := fut.req.Ctx()
+	reqid := conn.nextRequestId(ctx != nil)
+	shard := &conn.shard[reqid&(conn.opts.Concurrency-1)]
+	shard.rmut.Lock()
+	if atomic.LoadUint32(&conn.state) != connConnected {
+		shard.rmut.Unlock()
+		fut.SetError(ClientError{
+			ErrConnectionNotReady,
+			"client connection is not ready",
+		})
+		conn.markDone(fut)
+		return
+	}
+	atomic.StoreUint32(&fut.requestId, reqid)
+	pos := (reqid / conn.opts.Concurrency) & (requestsMap - 1)
+	if ctx != nil {
+		shard.requestsWithCtx[pos].addFuture(fut)
+	} else {
+		shard.requests[pos].addFuture(fut)
+		if conn.opts.Timeout > 0 {
+			fut.timeout = time.Since(epoch) + conn.opts.Timeout
+		}
+	}
+	shard.rmut.Unlock()
+
+	if ctx != nil {
+		select {
+		case <-ctx.Done():
+			conn.cancelFuture(fut, fmt.Errorf("context is done (request ID %d)", reqid))
+			return
+		default:
+		}
+	}
+	fut.mutex.Lock()
+	streamId := fut.streamId
+	fut.mutex.Unlock()
+	conn.putFuture(fut, fut.req, streamId)
 }
 
 // NewPrepared passes a sql statement to Tarantool for preparation synchronously.
@@ -85,6 +85,11 @@ func (req baseRequest) Async() bool {
 	return req.impl.Async()
 }
 
+// GetFunction returns the name of the called CRUD function.
+func (req baseRequest) GetFunction() string {
+	return req.impl.GetFunction()
+}
+
 // Response creates a response for the baseRequest.
 func (req baseRequest) Response(header tarantool.Header,
 	body io.Reader) (tarantool.Response, error) {
@@ -0,0 +1,213 @@
+package crud
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// DefaultScannerPageSize is a default number of rows fetched by a Scanner
+// with a single `crud.select` call.
+const DefaultScannerPageSize = 1000
+
+// ScannerOpts describes options for a Scanner.
+type ScannerOpts struct {
+	// PageSize is a maximum number of rows fetched with a single
+	// `crud.select` call. DefaultScannerPageSize is used if the value is
+	// zero.
+	PageSize uint
+}
+
+// Scanner iterates over rows of a `crud.select` result page by page. It uses
+// the last row of a page as SelectOpts.After value for the next page, so the
+// whole result is never loaded into memory.
+//
+// SelectOpts.First of the request is used as a limit of the total number of
+// rows, it must not be negative. A context of the request is used for all
+// calls of the scanner.
+//
+// The Scanner is not safe for concurrent use.
+type Scanner struct {
+	doer tarantool.Doer
+	req  SelectRequest
+	opts ScannerOpts
+
+	// limited is true if a number of rows is limited with SelectOpts.First.
+	limited bool
+	// remaining is a number of rows to fetch if limited is true.
+	remaining uint
+	// done is true when there are no more pages to fetch.
+	done bool
+
+	metadata []FieldFormat
+	rows     []msgpack.RawMessage
+	// pos is an index of the current row in rows.
+	pos int
+	err error
+}
+
+// NewScanner creates a new Scanner for the select request.
+func NewScanner(doer tarantool.Doer, req SelectRequest, opts ScannerOpts) *Scanner {
+	if opts.PageSize == 0 {
+		opts.PageSize = DefaultScannerPageSize
+	}
+
+	scanner := &Scanner{
+		doer: doer,
+		req:  req,
+		opts: opts,
+		pos:  -1,
+	}
+	if first, ok := req.opts.First.Get(); ok {
+		if first < 0 {
+			scanner.err = fmt.Errorf("negative First option is not supported")
+		} else {
+			scanner.limited = true
+			scanner.remaining = uint(first)
+		}
+	}
+	return scanner
+}
+
+// Next advances the scanner to the next row, a next page is fetched if
+// needed. It returns false when there are no more rows or an error happened,
+// Err() should be checked in the last case.
+func (s *Scanner) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	s.pos++
+	for s.pos >= len(s.rows) {
+		if s.done {
+			s.rows = nil
+			return false
+		}
+		if err := s.fetch(); err != nil {
+			s.err = err
+			s.rows = nil
+			return false
+		}
+	}
+	return true
+}
+
+// Metadata returns a format of rows from the last fetched page.
+func (s *Scanner) Metadata() []FieldFormat {
+	return s.metadata
+}
+
+// Row returns the current row as a raw msgpack array.
+func (s *Scanner) Row() msgpack.RawMessage {
+	if s.pos < 0 || s.pos >= len(s.rows) {
+		return nil
+	}
+	return s.rows[s.pos]
+}
+
+// Scan decodes the current row into the result. The row is decoded as a map
+// with field names from the metadata, so a result could be a pointer to a
+// struct with `msgpack` field tags or to a map.
+func (s *Scanner) Scan(result interface{}) error {
+	row := s.Row()
+	if row == nil {
+		return fmt.Errorf("no current row, call Next() first")
+	}
+
+	object, err := rowToObject(row, s.metadata)
+	if err != nil {
+		return err
+	}
+	return msgpack.Unmarshal(object, result)
+}
+
+// Err returns an error happened during the iteration.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// fetch fetches the next page.
+func (s *Scanner) fetch() error {
+	if ctx := s.req.Ctx(); ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	first := s.opts.PageSize
+	if s.limited && s.remaining < first {
+		first = s.remaining
+	}
+	if first == 0 {
+		s.done = true
+		s.rows = nil
+		return nil
+	}
+
+	opts := s.req.opts
+	opts.First = MakeOptInt(int(first))
+	if len(s.rows) > 0 {
+		opts.After = MakeOptTuple(s.rows[len(s.rows)-1])
+	}
+	req := s.req.Opts(opts)
+
+	result := MakeResult(reflect.TypeOf(msgpack.RawMessage{}))
+	if err := s.doer.Do(req).GetTyped(&result); err != nil {
+		return err
+	}
+
+	var rows []msgpack.RawMessage
+	if result.Rows != nil {
+		rows = result.Rows.([]msgpack.RawMessage)
+	}
+	if result.Metadata != nil {
+		s.metadata = result.Metadata
+	}
+	s.rows = rows
+	s.pos = 0
+
+	if s.limited {
+		s.remaining -= uint(len(rows))
+	}
+	if uint(len(rows)) < first || (s.limited && s.remaining == 0) {
+		s.done = true
+	}
+	return nil
+}
+
+// rowToObject converts a raw tuple into a raw map with field names from the
+// metadata.
+func rowToObject(row msgpack.RawMessage, metadata []FieldFormat) ([]byte, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(row))
+	l, err := dec.DecodeArrayLen()
+	if err != nil {
+		return nil, err
+	}
+	if l > len(metadata) {
+		return nil, fmt.Errorf("unexpected row length %d, metadata contains %d fields",
+			l, len(metadata))
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	if err := enc.EncodeMapLen(l); err != nil {
+		return nil, err
+	}
+	for i := 0; i < l; i++ {
+		field, err := dec.DecodeRaw()
+		if err != nil {
+			return nil, err
+		}
+		if err := enc.EncodeString(metadata[i].Name); err != nil {
+			return nil, err
+		}
+		if _, err := buf.Write(field); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,174 @@
+package crud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/crud"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const scannerSpaceLen = 7
+
+type scannerRow struct {
+	Id   uint   `msgpack:"id"`
+	Name string `msgpack:"name"`
+}
+
+type scannerArgs struct {
+	_msgpack   struct{} `msgpack:",asArray"` //nolint: structcheck,unused
+	Space      string
+	Conditions []interface{}
+	Opts       map[string]interface{}
+}
+
+// handleScannerSelect sets a handler of `crud.select` over a space of
+// scannerSpaceLen rows. It returns options of the calls.
+func handleScannerSelect(server *mockserver.Server) *[]map[string]interface{} {
+	var calls []map[string]interface{}
+	server.HandleCall("crud.select", func(req *mockserver.Request) ([]interface{}, error) {
+		var args scannerArgs
+		if err := req.Decode(iproto.IPROTO_TUPLE, &args); err != nil {
+			return nil, err
+		}
+		calls = append(calls, args.Opts)
+		if args.Space != "test" {
+			return []interface{}{nil, map[string]interface{}{
+				"class_name": "SelectError",
+				"err":        "Space \"" + args.Space + "\" doesn't exist",
+			}}, nil
+		}
+
+		start := 0
+		if after, ok := args.Opts["after"].([]interface{}); ok {
+			id, _ := after[0].(int8)
+			start = int(id) + 1
+		}
+		first := scannerSpaceLen
+		if value, ok := args.Opts["first"].(int8); ok {
+			first = int(value)
+		}
+
+		rows := []interface{}{}
+		for i := start; i < scannerSpaceLen && len(rows) < first; i++ {
+			rows = append(rows, []interface{}{i, string(rune('a' + i))})
+		}
+		return []interface{}{map[string]interface{}{
+			"metadata": []interface{}{
+				map[string]interface{}{"name": "id", "type": "unsigned"},
+				map[string]interface{}{"name": "name", "type": "string"},
+			},
+			"rows": rows,
+		}, nil}, nil
+	})
+	return &calls
+}
+
+func TestScanner(t *testing.T) {
+	cases := []struct {
+		name   string
+		req    crud.SelectRequest
+		opts   crud.ScannerOpts
+		ids    []uint
+		firsts []interface{}
+	}{
+		{
+			name:   "pages",
+			req:    crud.MakeSelectRequest("test"),
+			opts:   crud.ScannerOpts{PageSize: 3},
+			ids:    []uint{0, 1, 2, 3, 4, 5, 6},
+			firsts: []interface{}{int8(3), int8(3), int8(3)},
+		},
+		{
+			name:   "exact",
+			req:    crud.MakeSelectRequest("test"),
+			opts:   crud.ScannerOpts{PageSize: 7},
+			ids:    []uint{0, 1, 2, 3, 4, 5, 6},
+			firsts: []interface{}{int8(7), int8(7)},
+		},
+		{
+			name: "first and after",
+			req: crud.MakeSelectRequest("test").Opts(crud.SelectOpts{
+				First: crud.MakeOptInt(4),
+				After: crud.MakeOptTuple([]interface{}{1, "b"}),
+			}),
+			opts:   crud.ScannerOpts{PageSize: 3},
+			ids:    []uint{2, 3, 4, 5},
+			firsts: []interface{}{int8(3), int8(1)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mockserver.StartTest(t, mockserver.Opts{})
+			calls := handleScannerSelect(server)
+			conn := server.Connect(t, tarantool.Opts{})
+
+			scanner := crud.NewScanner(conn, tc.req, tc.opts)
+			var ids []uint
+			for scanner.Next() {
+				var row scannerRow
+				require.NoError(t, scanner.Scan(&row))
+				assert.Equal(t, string(rune('a'+row.Id)), row.Name)
+				ids = append(ids, row.Id)
+
+				var tuple []interface{}
+				require.NoError(t, msgpack.Unmarshal(scanner.Row(), &tuple))
+				assert.Len(t, tuple, 2)
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, tc.ids, ids)
+			require.Len(t, scanner.Metadata(), 2)
+			assert.Equal(t, "name", scanner.Metadata()[1].Name)
+
+			var firsts []interface{}
+			for _, opts := range *calls {
+				firsts = append(firsts, opts["first"])
+			}
+			assert.Equal(t, tc.firsts, firsts)
+		})
+	}
+}
+
+func TestScanner_Error(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleScannerSelect(server)
+	conn := server.Connect(t, tarantool.Opts{})
+
+	scanner := crud.NewScanner(conn, crud.MakeSelectRequest("invalid"), crud.ScannerOpts{})
+	assert.False(t, scanner.Next())
+	var crudErr crud.Error
+	require.ErrorAs(t, scanner.Err(), &crudErr)
+	assert.Equal(t, "SelectError", crudErr.ClassName)
+	assert.False(t, scanner.Next())
+
+	req := crud.MakeSelectRequest("test").Opts(crud.SelectOpts{
+		First: crud.MakeOptInt(-1),
+	})
+	scanner = crud.NewScanner(conn, req, crud.ScannerOpts{})
+	assert.False(t, scanner.Next())
+	assert.Error(t, scanner.Err())
+}
+
+func TestScanner_Context(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleScannerSelect(server)
+	conn := server.Connect(t, tarantool.Opts{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := crud.MakeSelectRequest("test").Context(ctx)
+	scanner := crud.NewScanner(conn, req, crud.ScannerOpts{PageSize: 2})
+
+	require.True(t, scanner.Next())
+	require.True(t, scanner.Next())
+	cancel()
+	assert.False(t, scanner.Next())
+	assert.ErrorIs(t, scanner.Err(), context.Canceled)
+}
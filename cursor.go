@@ -0,0 +1,200 @@
+package tarantool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// DefaultCursorBatchSize is a default number of tuples fetched by a Cursor
+// with a single request.
+const DefaultCursorBatchSize = 1000
+
+var errCursorClosed = errors.New("cursor is closed")
+
+// CursorOpts is a set of options for a Cursor.
+type CursorOpts struct {
+	// BatchSize is a maximum number of tuples fetched with a single
+	// request. DefaultCursorBatchSize is used if the value is zero.
+	BatchSize uint32
+	// Prefetch enables fetching of the next batch while the current one is
+	// processed.
+	Prefetch bool
+}
+
+// Cursor iterates over a result of a SelectRequest batch by batch. It uses
+// positions of the last selected tuples (SelectRequest.FetchPos and
+// SelectRequest.After) to fetch the next batch, so the whole result is never
+// loaded into memory.
+//
+// A context of the select request is used for all requests of the cursor,
+// so the iteration could be cancelled with the context.
+//
+// The Cursor is not safe for concurrent use.
+//
+// Requires Tarantool >= 2.11.
+type Cursor struct {
+	doer Doer
+	req  SelectRequest
+	opts CursorOpts
+
+	// remaining is a number of tuples to fetch according to the original
+	// request limit.
+	remaining uint32
+	// first is true until the first request is sent.
+	first bool
+	// done is true when there are no more batches to fetch.
+	done bool
+	// next is a future of the prefetched batch or nil.
+	next *Future
+
+	resp   *SelectResponse
+	tuples []msgpack.RawMessage
+	err    error
+}
+
+// NewCursor creates a new Cursor for the select request. An offset, a limit,
+// an iterator and a key of the request are taken into account. The request
+// is copied, so it could be reused after the call.
+func NewCursor(doer Doer, req *SelectRequest, opts CursorOpts) *Cursor {
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultCursorBatchSize
+	}
+	return &Cursor{
+		doer:      doer,
+		req:       *req,
+		opts:      opts,
+		remaining: req.limit,
+		first:     true,
+	}
+}
+
+// Next fetches the next batch. It returns false when there are no more
+// tuples or an error happened, Err() should be checked in the last case.
+func (c *Cursor) Next() bool {
+	c.resp = nil
+	c.tuples = nil
+	if c.err != nil {
+		return false
+	}
+
+	fut := c.next
+	c.next = nil
+	if fut == nil {
+		if c.done {
+			return false
+		}
+		fut = c.fetch()
+	}
+
+	if err := c.receive(fut); err != nil {
+		c.err = err
+		c.done = true
+		return false
+	}
+	if len(c.tuples) == 0 {
+		c.done = true
+		return false
+	}
+
+	if !c.done {
+		c.req.after = c.resp.pos
+		if c.opts.Prefetch {
+			c.next = c.fetch()
+		}
+	}
+	return true
+}
+
+// Len returns a number of tuples in the current batch.
+func (c *Cursor) Len() int {
+	return len(c.tuples)
+}
+
+// Decode decodes tuples of the current batch into the result. The result
+// must be a pointer to a slice, as for Future.GetTyped().
+func (c *Cursor) Decode(result interface{}) error {
+	if c.resp == nil {
+		return fmt.Errorf("no current batch, call Next() first")
+	}
+	return c.resp.DecodeTyped(result)
+}
+
+// Tuples returns raw tuples of the current batch.
+func (c *Cursor) Tuples() []msgpack.RawMessage {
+	return c.tuples
+}
+
+// Err returns an error happened during the iteration.
+func (c *Cursor) Err() error {
+	if errors.Is(c.err, errCursorClosed) {
+		return nil
+	}
+	return c.err
+}
+
+// Close stops the iteration. A prefetched batch is discarded.
+func (c *Cursor) Close() {
+	if c.err == nil {
+		c.err = errCursorClosed
+	}
+	c.done = true
+	c.next = nil
+	c.resp = nil
+	c.tuples = nil
+}
+
+// fetch sends a request for the next batch.
+func (c *Cursor) fetch() *Future {
+	limit := c.opts.BatchSize
+	if c.remaining < limit {
+		limit = c.remaining
+	}
+
+	req := c.req
+	req.fetchPos = true
+	req.limit = limit
+	if !c.first {
+		// The offset is applied to the first batch only.
+		req.offset = 0
+	}
+	c.first = false
+	return c.doer.Do(&req)
+}
+
+// receive waits for a batch and updates the state of the cursor.
+func (c *Cursor) receive(fut *Future) error {
+	if ctx := c.req.Ctx(); ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	resp, err := fut.GetResponse()
+	if err != nil {
+		return err
+	}
+	selectResp, ok := resp.(*SelectResponse)
+	if !ok {
+		return fmt.Errorf("unexpected response type %T", resp)
+	}
+
+	var tuples []msgpack.RawMessage
+	if err := selectResp.DecodeTyped(&tuples); err != nil {
+		return err
+	}
+
+	c.resp = selectResp
+	c.tuples = tuples
+
+	requested := c.opts.BatchSize
+	if c.remaining < requested {
+		requested = c.remaining
+	}
+	c.remaining -= uint32(len(tuples))
+	if uint32(len(tuples)) < requested || c.remaining == 0 || len(selectResp.pos) == 0 {
+		c.done = true
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package tarantool_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const cursorSpaceLen = 10
+
+type cursorTuple struct {
+	_msgpack struct{} `msgpack:",asArray"` //nolint: structcheck,unused
+	Id       uint
+	Name     string
+}
+
+// handleCursorSpace sets a handler of selects from a space of
+// cursorSpaceLen tuples. A position of a tuple is its string id.
+func handleCursorSpace(server *mockserver.Server) {
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		start := 0
+		if req.Has(iproto.IPROTO_AFTER_POSITION) {
+			var pos []byte
+			if err := req.Decode(iproto.IPROTO_AFTER_POSITION, &pos); err != nil {
+				return nil, err
+			}
+			last, err := strconv.Atoi(string(pos))
+			if err != nil {
+				return nil, Error{Code: iproto.ER_ITERATOR_POSITION, Msg: "bad position"}
+			}
+			start = last + 1
+		}
+		start += int(req.Offset())
+
+		var tuples []interface{}
+		for i := start; i < cursorSpaceLen && uint32(len(tuples)) < req.Limit(); i++ {
+			tuples = append(tuples, []interface{}{i, "name" + strconv.Itoa(i)})
+		}
+		var fetchPos bool
+		req.Decode(iproto.IPROTO_FETCH_POSITION, &fetchPos)
+		if fetchPos && len(tuples) > 0 {
+			last := start + len(tuples) - 1
+			req.SetResponseField(iproto.IPROTO_POSITION, []byte(strconv.Itoa(last)))
+		}
+		return tuples, nil
+	})
+}
+
+func scanCursor(t *testing.T, cursor *Cursor) ([]uint, []int) {
+	t.Helper()
+
+	var ids []uint
+	var sizes []int
+	for cursor.Next() {
+		var tuples []cursorTuple
+		require.NoError(t, cursor.Decode(&tuples))
+		require.Len(t, tuples, cursor.Len())
+		sizes = append(sizes, cursor.Len())
+		for _, tuple := range tuples {
+			assert.Equal(t, "name"+strconv.Itoa(int(tuple.Id)), tuple.Name)
+			ids = append(ids, tuple.Id)
+		}
+	}
+	require.NoError(t, cursor.Err())
+	return ids, sizes
+}
+
+func TestCursor(t *testing.T) {
+	cases := []struct {
+		name  string
+		req   *SelectRequest
+		opts  CursorOpts
+		ids   []uint
+		sizes []int
+	}{
+		{
+			name:  "batches",
+			req:   NewSelectRequest(512).Index(0),
+			opts:  CursorOpts{BatchSize: 3},
+			ids:   []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			sizes: []int{3, 3, 3, 1},
+		},
+		{
+			name:  "prefetch",
+			req:   NewSelectRequest(512).Index(0),
+			opts:  CursorOpts{BatchSize: 3, Prefetch: true},
+			ids:   []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			sizes: []int{3, 3, 3, 1},
+		},
+		{
+			name:  "exact",
+			req:   NewSelectRequest(512).Index(0),
+			opts:  CursorOpts{BatchSize: 5},
+			ids:   []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			sizes: []int{5, 5},
+		},
+		{
+			name:  "default batch size",
+			req:   NewSelectRequest(512).Index(0),
+			ids:   []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			sizes: []int{10},
+		},
+		{
+			name:  "offset and limit",
+			req:   NewSelectRequest(512).Index(0).Offset(2).Limit(5),
+			opts:  CursorOpts{BatchSize: 2, Prefetch: true},
+			ids:   []uint{2, 3, 4, 5, 6},
+			sizes: []int{2, 2, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mockserver.StartTest(t, mockserver.Opts{})
+			handleCursorSpace(server)
+			conn := server.Connect(t, Opts{})
+
+			ids, sizes := scanCursor(t, NewCursor(conn, tc.req, tc.opts))
+			assert.Equal(t, tc.ids, ids)
+			assert.Equal(t, tc.sizes, sizes)
+
+			var offsets []uint32
+			for _, req := range server.Requests() {
+				if req.Type == iproto.IPROTO_SELECT {
+					offsets = append(offsets, req.Offset())
+				}
+			}
+			require.NotEmpty(t, offsets)
+			for _, offset := range offsets[1:] {
+				assert.Zero(t, offset)
+			}
+		})
+	}
+}
+
+func TestCursor_Error(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleCursorSpace(server)
+	conn := server.Connect(t, Opts{})
+
+	req := NewSelectRequest(512).Index(0).After([]byte("invalid"))
+	cursor := NewCursor(conn, req, CursorOpts{BatchSize: 3})
+	assert.False(t, cursor.Next())
+
+	var tntErr Error
+	require.ErrorAs(t, cursor.Err(), &tntErr)
+	assert.Equal(t, iproto.ER_ITERATOR_POSITION, tntErr.Code)
+	assert.False(t, cursor.Next())
+}
+
+func TestCursor_Context(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleCursorSpace(server)
+	conn := server.Connect(t, Opts{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := NewSelectRequest(512).Index(0).Context(ctx)
+	cursor := NewCursor(conn, req, CursorOpts{BatchSize: 3, Prefetch: true})
+
+	require.True(t, cursor.Next())
+	cancel()
+	assert.False(t, cursor.Next())
+	assert.ErrorIs(t, cursor.Err(), context.Canceled)
+}
+
+func TestCursor_Close(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleCursorSpace(server)
+	conn := server.Connect(t, Opts{})
+
+	cursor := NewCursor(conn, NewSelectRequest(512).Index(0), CursorOpts{BatchSize: 3})
+	require.True(t, cursor.Next())
+	cursor.Close()
+	assert.False(t, cursor.Next())
+	assert.NoError(t, cursor.Err())
+	assert.Error(t, cursor.Decode(&[]cursorTuple{}))
+}
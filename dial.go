@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -271,6 +273,161 @@ func (d FdDialer) Dial(ctx context.Context, opts DialOpts) (Conn, error) {
 	return dialer.Dial(ctx, opts)
 }
 
+type tlsDialer struct {
+	address string
+	config  *tls.Config
+}
+
+func (d tlsDialer) Dial(ctx context.Context, opts DialOpts) (Conn, error) {
+	var err error
+	conn := new(tntConn)
+
+	network, address := parseAddress(d.address)
+	dialer := tls.Dialer{Config: d.config}
+	conn.net, err = dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial: %w", err)
+	}
+
+	dc := &deadlineIO{to: opts.IoTimeout, c: conn.net}
+	conn.reader = bufio.NewReaderSize(dc, bufSize)
+	conn.writer = bufio.NewWriterSize(dc, bufSize)
+
+	return conn, nil
+}
+
+// TLSDialer allows using SSL transport for connection. It is based on the
+// crypto/tls package.
+type TLSDialer struct {
+	// Address is an address to connect. The format is the same as for
+	// NetDialer.Address.
+	Address string
+	// Auth is an authentication method. By default, a method is selected
+	// automatically (a value from IPROTO_ID response or ChapSha1Auth).
+	Auth Auth
+	// Username for logging in to Tarantool.
+	User string
+	// User password for logging in to Tarantool.
+	Password string
+	// RequiredProtocol contains minimal protocol version and
+	// list of protocol features that should be supported by
+	// Tarantool server. By default, there are no restrictions.
+	RequiredProtocolInfo ProtocolInfo
+	// SslKeyFile is a path to a private SSL key file.
+	SslKeyFile string
+	// SslCertFile is a path to an SSL certificate file.
+	SslCertFile string
+	// SslCaFile is a path to a trusted certificate authorities (CA) file. By
+	// default, the system CA pool is used.
+	SslCaFile string
+	// SslCiphers is a list of enabled cipher suites (tls.TLS_* constants).
+	// By default, a list from the crypto/tls package is used. The list is
+	// ignored for TLS 1.3.
+	SslCiphers []uint16
+	// SslMinVersion is a minimum TLS version (tls.VersionTLS* constants).
+	// By default, the minimum version from the crypto/tls package is used.
+	SslMinVersion uint16
+	// ServerName is a server name to verify a certificate of the server
+	// (SNI). By default, a host part of the Address is used.
+	ServerName string
+	// InsecureSkipVerify disables verification of a server certificate
+	// chain and host name. It should be used only for testing.
+	InsecureSkipVerify bool
+
+	// files are loaded SSL files, see Load().
+	files *tlsFiles
+}
+
+// tlsFiles are parsed SSL files of TLSDialer.
+type tlsFiles struct {
+	rootCAs      *x509.CertPool
+	certificates []tls.Certificate
+}
+
+// Load reads and parses the SSL files and returns a copy of the dialer that
+// uses them for every dial, including reconnects. Otherwise, the files are
+// read on every dial. Load could be called again to reload the files, for
+// example, after a certificate rotation.
+func (d TLSDialer) Load() (TLSDialer, error) {
+	files, err := d.loadFiles()
+	if err != nil {
+		return d, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	d.files = files
+	return d, nil
+}
+
+// Dial makes TLSDialer satisfy the Dialer interface.
+func (d TLSDialer) Dial(ctx context.Context, opts DialOpts) (Conn, error) {
+	config, err := d.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+
+	dialer := AuthDialer{
+		Dialer: ProtocolDialer{
+			Dialer: GreetingDialer{
+				Dialer: tlsDialer{
+					address: d.Address,
+					config:  config,
+				},
+			},
+			RequiredProtocolInfo: d.RequiredProtocolInfo,
+		},
+		Auth:     d.Auth,
+		Username: d.User,
+		Password: d.Password,
+	}
+
+	return dialer.Dial(ctx, opts)
+}
+
+// tlsConfig creates a TLS configuration from the dialer options.
+func (d TLSDialer) tlsConfig() (*tls.Config, error) {
+	files := d.files
+	if files == nil {
+		var err error
+		if files, err = d.loadFiles(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tls.Config{
+		RootCAs:            files.rootCAs,
+		Certificates:       files.certificates,
+		CipherSuites:       d.SslCiphers,
+		MinVersion:         d.SslMinVersion,
+		ServerName:         d.ServerName,
+		InsecureSkipVerify: d.InsecureSkipVerify,
+	}, nil
+}
+
+// loadFiles reads and parses the SSL files of the dialer.
+func (d TLSDialer) loadFiles() (*tlsFiles, error) {
+	files := &tlsFiles{}
+
+	if d.SslCaFile != "" {
+		ca, err := os.ReadFile(d.SslCaFile)
+		if err != nil {
+			return nil, err
+		}
+		files.rootCAs = x509.NewCertPool()
+		if !files.rootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %q", d.SslCaFile)
+		}
+	}
+
+	if d.SslCertFile != "" || d.SslKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(d.SslCertFile, d.SslKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		files.certificates = []tls.Certificate{cert}
+	}
+
+	return files, nil
+}
+
 // AuthDialer is a dialer-wrapper that does authentication of a user.
 type AuthDialer struct {
 	// Dialer is a base dialer.
@@ -523,7 +680,7 @@ func authenticate(c Conn, auth Auth, user string, pass string, salt string) erro
 // writeRequest writes a request to the writer.
 func writeRequest(w writeFlusher, req Request) error {
 	var packet smallWBuf
-	err := pack(&packet, msgpack.NewEncoder(&packet), 0, req, ignoreStreamId, nil)
+	_, err := pack(&packet, msgpack.NewEncoder(&packet), 0, req, ignoreStreamId, nil, nil)
 
 	if err != nil {
 		return fmt.Errorf("pack error: %w", err)
@@ -541,15 +698,25 @@ func writeRequest(w writeFlusher, req Request) error {
 func readResponse(r io.Reader, req Request) (Response, error) {
 	var lenbuf [packetLengthBytes]byte
 
-	respBytes, err := read(r, lenbuf[:])
-	if err != nil {
-		return nil, fmt.Errorf("read error: %w", err)
-	}
+	var buf smallBuf
+	var header Header
+	for {
+		respBytes, err := read(r, lenbuf[:])
+		if err != nil {
+			return nil, fmt.Errorf("read error: %w", err)
+		}
 
-	buf := smallBuf{b: respBytes}
-	header, _, err := decodeHeader(msgpack.NewDecoder(&smallBuf{}), &buf)
-	if err != nil {
-		return nil, fmt.Errorf("decode response header error: %w", err)
+		var code iproto.Type
+		buf = smallBuf{b: respBytes}
+		header, code, err = decodeHeader(msgpack.NewDecoder(&smallBuf{}), &buf)
+		if err != nil {
+			return nil, fmt.Errorf("decode response header error: %w", err)
+		}
+		// Push messages (box.session.push()) are skipped, the response
+		// follows them.
+		if code != iproto.IPROTO_CHUNK {
+			break
+		}
 	}
 	resp, err := req.Response(header, &buf)
 	if err != nil {
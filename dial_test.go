@@ -3,10 +3,13 @@ package tarantool_test
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -676,6 +679,233 @@ func TestFdDialer_Dial_requirements(t *testing.T) {
 	require.Contains(t, err.Error(), "invalid server protocol")
 }
 
+func listenTLS(t *testing.T, verifyClient bool) (net.Listener, test_helpers.Certs) {
+	t.Helper()
+
+	certs, err := test_helpers.GenerateCerts(t.TempDir())
+	require.NoError(t, err)
+	config, err := certs.ServerTLSConfig(verifyClient)
+	require.NoError(t, err)
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	return l, certs
+}
+
+func TestTLSDialer_Dial(t *testing.T) {
+	l, certs := listenTLS(t, true)
+	defer l.Close()
+	dialer := tarantool.TLSDialer{
+		Address:     l.Addr().String(),
+		Auth:        tarantool.ChapSha1Auth,
+		User:        testDialUser,
+		Password:    testDialPass,
+		SslCaFile:   certs.CaFile,
+		SslCertFile: certs.ClientCertFile,
+		SslKeyFile:  certs.ClientKeyFile,
+	}
+	cases := []testDialOpts{
+		{
+			name:                 "all is ok",
+			expectedProtocolInfo: idResponseTyped.Clone(),
+		},
+		{
+			name:                 "id request unsupported",
+			expectedProtocolInfo: tarantool.ProtocolInfo{},
+			isIdUnsupported:      true,
+		},
+		{
+			name:          "greeting response error",
+			wantErr:       true,
+			expectedErr:   "failed to read greeting",
+			isErrGreeting: true,
+		},
+		{
+			name:        "id response error",
+			wantErr:     true,
+			expectedErr: "failed to identify",
+			isErrId:     true,
+		},
+		{
+			name:        "auth response error",
+			wantErr:     true,
+			expectedErr: "failed to authenticate",
+			isErrAuth:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			testDialer(t, l, dialer, tc)
+		})
+	}
+}
+
+func TestTLSDialer_Dial_options(t *testing.T) {
+	l, certs := listenTLS(t, false)
+	defer l.Close()
+
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		dialer tarantool.TLSDialer
+	}{
+		{
+			name: "ca file",
+			dialer: tarantool.TLSDialer{
+				SslCaFile: certs.CaFile,
+			},
+		},
+		{
+			name: "server name",
+			dialer: tarantool.TLSDialer{
+				SslCaFile:  certs.CaFile,
+				ServerName: "localhost",
+			},
+		},
+		{
+			name: "insecure skip verify",
+			dialer: tarantool.TLSDialer{
+				InsecureSkipVerify: true,
+			},
+		},
+		{
+			name: "min version and ciphers",
+			dialer: tarantool.TLSDialer{
+				SslCaFile:     certs.CaFile,
+				SslMinVersion: tls.VersionTLS12,
+				SslCiphers: []uint16{
+					tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				},
+			},
+		},
+		{
+			name: "localhost",
+			dialer: tarantool.TLSDialer{
+				Address:   net.JoinHostPort("localhost", port),
+				SslCaFile: certs.CaFile,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.dialer.Address == "" {
+				tc.dialer.Address = l.Addr().String()
+			}
+			tc.dialer.Auth = tarantool.ChapSha1Auth
+			tc.dialer.User = testDialUser
+			tc.dialer.Password = testDialPass
+
+			testDialer(t, l, tc.dialer, testDialOpts{
+				expectedProtocolInfo: idResponseTyped.Clone(),
+			})
+		})
+	}
+}
+
+func TestTLSDialer_Dial_error(t *testing.T) {
+	l, certs := listenTLS(t, true)
+	defer l.Close()
+
+	otherCerts, err := test_helpers.GenerateCerts(t.TempDir())
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		dialer      tarantool.TLSDialer
+		expectedErr string
+	}{
+		{
+			name: "unknown ca",
+			dialer: tarantool.TLSDialer{
+				SslCaFile:   otherCerts.CaFile,
+				SslCertFile: certs.ClientCertFile,
+				SslKeyFile:  certs.ClientKeyFile,
+			},
+			expectedErr: "failed to dial",
+		},
+		{
+			name: "wrong server name",
+			dialer: tarantool.TLSDialer{
+				SslCaFile:   certs.CaFile,
+				SslCertFile: certs.ClientCertFile,
+				SslKeyFile:  certs.ClientKeyFile,
+				ServerName:  "example.com",
+			},
+			expectedErr: "failed to dial",
+		},
+		{
+			name: "no client certificate",
+			dialer: tarantool.TLSDialer{
+				SslCaFile: certs.CaFile,
+			},
+			expectedErr: "failed to read greeting",
+		},
+		{
+			name: "invalid ca file",
+			dialer: tarantool.TLSDialer{
+				SslCaFile: certs.ClientKeyFile,
+			},
+			expectedErr: "failed to configure TLS",
+		},
+		{
+			name: "no ca file",
+			dialer: tarantool.TLSDialer{
+				SslCaFile: filepath.Join(t.TempDir(), "ca.crt"),
+			},
+			expectedErr: "failed to configure TLS",
+		},
+		{
+			name: "invalid key pair",
+			dialer: tarantool.TLSDialer{
+				SslCaFile:   certs.CaFile,
+				SslCertFile: certs.ClientCertFile,
+				SslKeyFile:  certs.ServerKeyFile,
+			},
+			expectedErr: "failed to configure TLS",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.dialer.Address = l.Addr().String()
+			testDialer(t, l, tc.dialer, testDialOpts{
+				wantErr:     true,
+				expectedErr: tc.expectedErr,
+			})
+		})
+	}
+}
+
+func TestTLSDialer_Load(t *testing.T) {
+	l, certs := listenTLS(t, true)
+	defer l.Close()
+
+	dialer, err := tarantool.TLSDialer{
+		Address:     l.Addr().String(),
+		Auth:        tarantool.ChapSha1Auth,
+		User:        testDialUser,
+		Password:    testDialPass,
+		SslCaFile:   certs.CaFile,
+		SslCertFile: certs.ClientCertFile,
+		SslKeyFile:  certs.ClientKeyFile,
+	}.Load()
+	require.NoError(t, err)
+
+	// The loaded files are not read again.
+	for _, file := range []string{certs.CaFile, certs.ClientCertFile, certs.ClientKeyFile} {
+		require.NoError(t, os.Remove(file))
+	}
+	for i := 0; i < 2; i++ {
+		testDialer(t, l, dialer, testDialOpts{
+			expectedProtocolInfo: idResponseTyped.Clone(),
+		})
+	}
+
+	_, err = dialer.Load()
+	assert.ErrorContains(t, err, "failed to configure TLS")
+}
+
 func TestAuthDialer_Dial_DialerError(t *testing.T) {
 	dialer := tarantool.AuthDialer{
 		Dialer: mockErrorDialer{
@@ -43,9 +43,12 @@ func (clierr ClientError) Error() string {
 // - request is timeouted
 //
 // - request is aborted due to rate limit
+//
+// - request is aborted due to an open circuit breaker
 func (clierr ClientError) Temporary() bool {
 	switch clierr.Code {
-	case ErrConnectionNotReady, ErrTimeouted, ErrRateLimited, ErrIoError:
+	case ErrConnectionNotReady, ErrTimeouted, ErrRateLimited, ErrIoError,
+		ErrCircuitOpen:
 		return true
 	default:
 		return false
@@ -61,4 +64,5 @@ const (
 	ErrRateLimited        = 0x4000 + iota
 	ErrConnectionShutdown = 0x4000 + iota
 	ErrIoError            = 0x4000 + iota
+	ErrCircuitOpen        = 0x4000 + iota
 )
@@ -54,6 +54,36 @@ func ExampleIntKey() {
 	// Data [{{} 1111 hello world}]
 }
 
+func ExampleDoTyped() {
+	conn := exampleConnect(dialer, opts)
+	defer conn.Close()
+
+	tuple := []interface{}{int(1111), "hello", "world"}
+	conn.Do(tarantool.NewReplaceRequest("test").Tuple(tuple)).Get()
+
+	// The result type is checked at compile time.
+	tuples, err := tarantool.DoTyped[Tuple](conn, tarantool.NewSelectRequest("test").
+		Index("primary").
+		Iterator(tarantool.IterEq).
+		Key(tarantool.IntKey{1111}))
+	fmt.Println("Error", err)
+	fmt.Println("Data", tuples)
+
+	one, ok, err := tarantool.GetOne[Tuple](conn, tarantool.NewSelectRequest("test").
+		Index("primary").
+		Iterator(tarantool.IterEq).
+		Key(tarantool.IntKey{1111}))
+	fmt.Println("Error", err)
+	fmt.Println("Found", ok)
+	fmt.Println("Tuple", one.Msg, one.Name)
+	// Output:
+	// Error <nil>
+	// Data [{{} 1111 hello world}]
+	// Error <nil>
+	// Found true
+	// Tuple hello world
+}
+
 func ExampleUintKey() {
 	conn := exampleConnect(dialer, opts)
 	defer conn.Close()
@@ -1,9 +1,13 @@
 package tarantool
 
 import (
+	"fmt"
 	"io"
 	"sync"
 	"time"
+
+	"github.com/tarantool/go-iproto"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 // Future is a handle for asynchronous request.
@@ -12,14 +16,32 @@ type Future struct {
 	req       Request
 	next      *Future
 	timeout   time.Duration
+	start     time.Time
 	mutex     sync.Mutex
 	pushes    []Response
 	resp      Response
 	err       error
 	ready     chan struct{}
 	done      chan struct{}
+	// traceFinish finishes tracing of the request, see Opts.Tracer.
+	traceFinish TraceFinish
+	// streamId is a stream of the sent request.
+	streamId uint64
+	// schemaVersion is a schema version sent with the request.
+	schemaVersion uint64
+	// schemaRetried is true if the request has been sent again after
+	// ER_WRONG_SCHEMA_VERSION.
+	schemaRetried bool
+	// retryHooks are called in order with a result of the future before it
+	// is finished, see setRetryHook().
+	retryHooks []retryHook
 }
 
+// retryHook is called with a result of a future under the lock of the
+// future. If it returns true, the future is not finished and the hook must
+// finish it later with setResult().
+type retryHook func(resp Response, err error) bool
+
 func (fut *Future) wait() {
 	if fut.done == nil {
 		return
@@ -59,9 +81,15 @@ func (it *asyncResponseIterator) Next() bool {
 	for !exit {
 		// We try to read at least once.
 		it.fut.mutex.Lock()
-		it.resp = it.nextResponse()
-		it.err = it.fut.err
-		last = it.resp == it.fut.resp
+		// A result of a retried request is not final until the future is
+		// done.
+		done := it.fut.isDone()
+		it.resp = it.nextResponse(done)
+		it.err = nil
+		if done {
+			it.err = it.fut.err
+		}
+		last = done && it.resp == it.fut.resp
 		it.fut.mutex.Unlock()
 
 		if it.timeout == 0 || it.resp != nil || it.err != nil {
@@ -106,13 +134,13 @@ func (it *asyncResponseIterator) WithTimeout(timeout time.Duration) TimeoutRespo
 	return it
 }
 
-func (it *asyncResponseIterator) nextResponse() (resp Response) {
+func (it *asyncResponseIterator) nextResponse(done bool) (resp Response) {
 	fut := it.fut
 	pushesLen := len(fut.pushes)
 
 	if it.curPos < pushesLen {
 		resp = fut.pushes[it.curPos]
-	} else if it.curPos == pushesLen {
+	} else if it.curPos == pushesLen && done {
 		resp = fut.resp
 	}
 
@@ -158,10 +186,43 @@ func (fut *Future) AppendPush(header Header, body io.Reader) error {
 	if err != nil {
 		return err
 	}
-	fut.pushes = append(fut.pushes, resp)
+	fut.appendPush(resp)
+	return nil
+}
 
+// appendPush appends the decoded push response to the future. It must be
+// called under the lock of the future.
+func (fut *Future) appendPush(resp Response) {
+	fut.pushes = append(fut.pushes, resp)
 	fut.ready <- struct{}{}
-	return nil
+}
+
+// forwardPushes appends push responses of the future to another future
+// until the future is done.
+func (fut *Future) forwardPushes(to *Future) {
+	if fut.ready == nil {
+		return
+	}
+
+	forwarded := 0
+	for {
+		_, ok := <-fut.ready
+		fut.mutex.Lock()
+		pushes := fut.pushes[forwarded:]
+		forwarded = len(fut.pushes)
+		fut.mutex.Unlock()
+
+		to.mutex.Lock()
+		if !to.isDone() {
+			for _, resp := range pushes {
+				to.appendPush(resp)
+			}
+		}
+		to.mutex.Unlock()
+		if !ok {
+			return
+		}
+	}
 }
 
 // SetResponse sets a response for the future and finishes the future.
@@ -177,10 +238,7 @@ func (fut *Future) SetResponse(header Header, body io.Reader) error {
 	if err != nil {
 		return err
 	}
-	fut.resp = resp
-
-	close(fut.ready)
-	close(fut.done)
+	fut.finish(resp, nil)
 	return nil
 }
 
@@ -192,7 +250,70 @@ func (fut *Future) SetError(err error) {
 	if fut.isDone() {
 		return
 	}
+	fut.finish(nil, err)
+}
+
+// setSent remembers how the request has been sent.
+func (fut *Future) setSent(streamId uint64, schemaVersion uint64) {
+	fut.mutex.Lock()
+	defer fut.mutex.Unlock()
+
+	fut.streamId = streamId
+	fut.schemaVersion = schemaVersion
+}
+
+// retrySchema returns true if the request must be sent again after a
+// response with the header: it has been rejected due to a stale schema for
+// the first time.
+func (fut *Future) retrySchema(header Header) bool {
+	fut.mutex.Lock()
+	defer fut.mutex.Unlock()
+
+	if header.Error != iproto.ER_WRONG_SCHEMA_VERSION ||
+		fut.schemaVersion == 0 || fut.schemaRetried {
+		return false
+	}
+	fut.schemaRetried = true
+	return true
+}
+
+// setResult sets a response and an error for the future and finishes the
+// future.
+func (fut *Future) setResult(resp Response, err error) {
+	fut.mutex.Lock()
+	defer fut.mutex.Unlock()
+
+	if fut.isDone() {
+		return
+	}
+	fut.finish(resp, err)
+}
+
+// setRetryHook adds a hook that could retry the request instead of
+// finishing the future. It returns false if the future is already done.
+func (fut *Future) setRetryHook(hook retryHook) bool {
+	fut.mutex.Lock()
+	defer fut.mutex.Unlock()
+
+	if fut.isDone() {
+		return false
+	}
+	fut.retryHooks = append(fut.retryHooks, hook)
+	return true
+}
+
+// finish sets the result and finishes the future unless a retry hook takes
+// it over. It must be called under the lock of the future.
+func (fut *Future) finish(resp Response, err error) {
+	fut.resp = resp
 	fut.err = err
+	for len(fut.retryHooks) > 0 {
+		hook := fut.retryHooks[0]
+		fut.retryHooks = fut.retryHooks[1:]
+		if hook(resp, err) {
+			return
+		}
+	}
 
 	close(fut.ready)
 	close(fut.done)
@@ -235,6 +356,30 @@ func (fut *Future) GetTyped(result interface{}) error {
 	return fut.resp.DecodeTyped(result)
 }
 
+// GetRaw waits for Future to be filled and returns msgpack encoded elements
+// of the response data without decoding, for example, tuples of a select
+// response. It allows to pass the data through without allocation of Go
+// values. The returned slices refer to the response buffer and must not be
+// modified.
+//
+// The response is decoded only if it contains an error.
+func (fut *Future) GetRaw() ([]msgpack.RawMessage, error) {
+	fut.wait()
+	if fut.err != nil {
+		return nil, fut.err
+	}
+	if fut.resp.Header().Error != ErrorNo {
+		if _, err := fut.resp.Decode(); err != nil {
+			return nil, err
+		}
+	}
+	rawResp, ok := fut.resp.(RawResponse)
+	if !ok {
+		return nil, fmt.Errorf("response type %T does not support raw access", fut.resp)
+	}
+	return rawResp.RawData()
+}
+
 // GetIterator returns an iterator for iterating through push messages
 // and a response. Push messages and the response will contain deserialized
 // result in Data field as for the Get() function.
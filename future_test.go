@@ -396,3 +396,37 @@ func TestFuture_GetResponse(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []interface{}{uint8('v'), uint8('2')}, data)
 }
+
+func TestFuture_GetRaw(t *testing.T) {
+	fut := NewFuture(NewSelectRequest(512))
+	body, err := msgpack.Marshal(map[iproto.Key]interface{}{
+		iproto.IPROTO_DATA: []interface{}{[]interface{}{1, "a"}},
+	})
+	assert.NoError(t, err)
+	fut.SetResponse(Header{}, bytes.NewReader(body))
+
+	data, err := fut.GetRaw()
+	assert.NoError(t, err)
+	expected, err := msgpack.Marshal([]interface{}{1, "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []msgpack.RawMessage{expected}, data)
+}
+
+func TestFuture_GetRaw_error(t *testing.T) {
+	fut := NewFuture(NewSelectRequest(512))
+	body, err := msgpack.Marshal(map[iproto.Key]interface{}{
+		iproto.IPROTO_ERROR_24: "unknown space",
+	})
+	assert.NoError(t, err)
+	fut.SetResponse(Header{Error: iproto.ER_NO_SUCH_SPACE}, bytes.NewReader(body))
+
+	_, err = fut.GetRaw()
+	var tntErr Error
+	assert.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_NO_SUCH_SPACE, tntErr.Code)
+
+	fut = NewFuture(&futureMockRequest{})
+	fut.SetResponse(Header{}, bytes.NewReader([]byte{'v', '2'}))
+	_, err = fut.GetRaw()
+	assert.Error(t, err)
+}
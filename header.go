@@ -11,4 +11,8 @@ type Header struct {
 	// Error == ErrorNo (iproto.ER_UNKNOWN) if there is no error.
 	// Otherwise, it contains an error code from iproto.Error enumeration.
 	Error iproto.Error
+	// SchemaVersion is a version of the database schema at the moment the
+	// response was created (IPROTO_SCHEMA_VERSION). It is zero if the
+	// response has no schema version.
+	SchemaVersion uint64
 }
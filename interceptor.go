@@ -0,0 +1,43 @@
+package tarantool
+
+// Interceptor intercepts a request passed to a Doer. It could inspect or
+// replace the request, pass it to the next Doer in a chain with next.Do(),
+// inspect or replace the resulting Future, or complete the request by
+// itself without calling next.Do().
+//
+// An interceptor is called synchronously, so it should not block. To
+// process a resolved Future, an interceptor could wait for it in a separate
+// goroutine and return another Future, see NewFuture().
+//
+// Interceptors could be set with Opts.Interceptors.
+type Interceptor func(req Request, next Doer) *Future
+
+// DoerFunc is an adapter to use an ordinary function as a Doer.
+type DoerFunc func(req Request) *Future
+
+// Do calls f(req).
+func (f DoerFunc) Do(req Request) *Future {
+	return f(req)
+}
+
+// ChainInterceptors composes interceptors into a single one. The first
+// interceptor is the outermost one: it receives a request first and its
+// next Doer calls the second interceptor and so on.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	return func(req Request, next Doer) *Future {
+		return chainDoer(interceptors, next).Do(req)
+	}
+}
+
+// chainDoer returns a Doer that passes a request through the interceptors
+// to the last Doer.
+func chainDoer(interceptors []Interceptor, last Doer) Doer {
+	doer := last
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], doer
+		doer = DoerFunc(func(req Request) *Future {
+			return interceptor(req, next)
+		})
+	}
+	return doer
+}
@@ -0,0 +1,148 @@
+package tarantool_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+type interceptorLog struct {
+	mutex sync.Mutex
+	calls []string
+}
+
+// interceptor returns an interceptor that logs the name for requests of the
+// type.
+func (l *interceptorLog) interceptor(name string, rtype iproto.Type) Interceptor {
+	return func(req Request, next Doer) *Future {
+		if req.Type() == rtype {
+			l.mutex.Lock()
+			l.calls = append(l.calls, name)
+			l.mutex.Unlock()
+		}
+		return next.Do(req)
+	}
+}
+
+func (l *interceptorLog) get() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]string(nil), l.calls...)
+}
+
+func TestConnection_Interceptors(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("echo", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{req.FunctionName()}, nil
+	})
+	server.HandleCall("replaced", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{req.FunctionName()}, nil
+	})
+
+	log := &interceptorLog{}
+	replace := func(req Request, next Doer) *Future {
+		if req.Type() == iproto.IPROTO_CALL {
+			return next.Do(NewCallRequest("replaced"))
+		}
+		return next.Do(req)
+	}
+	conn := server.Connect(t, Opts{Interceptors: []Interceptor{
+		log.interceptor("first", iproto.IPROTO_CALL),
+		ChainInterceptors(log.interceptor("second", iproto.IPROTO_CALL),
+			log.interceptor("third", iproto.IPROTO_CALL)),
+		replace,
+	}})
+
+	data, err := conn.Do(NewCallRequest("echo")).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"replaced"}, data)
+	assert.Equal(t, []string{"first", "second", "third"}, log.get())
+}
+
+func TestConnection_Interceptors_shortCircuit(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	errDenied := errors.New("denied")
+	deny := func(req Request, next Doer) *Future {
+		if req.Type() == iproto.IPROTO_PING {
+			fut := NewFuture(req)
+			fut.SetError(errDenied)
+			return fut
+		}
+		return next.Do(req)
+	}
+	conn := server.Connect(t, Opts{Interceptors: []Interceptor{deny}})
+
+	_, err := conn.Do(NewPingRequest()).Get()
+	assert.ErrorIs(t, err, errDenied)
+
+	for _, req := range server.Requests() {
+		assert.NotEqual(t, iproto.IPROTO_PING, req.Type)
+	}
+}
+
+func TestConnection_Interceptors_future(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("fail", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, Error{Code: iproto.ER_PROC_LUA, Msg: "fail"}
+	})
+
+	errs := make(chan error, 1)
+	observe := func(req Request, next Doer) *Future {
+		fut := next.Do(req)
+		if req.Type() != iproto.IPROTO_CALL {
+			return fut
+		}
+		go func() {
+			resp, err := fut.GetResponse()
+			if err == nil && resp.Header().Error != ErrorNo {
+				err = Error{Code: resp.Header().Error}
+			}
+			errs <- err
+		}()
+		return fut
+	}
+	conn := server.Connect(t, Opts{Interceptors: []Interceptor{observe}})
+
+	_, err := conn.Do(NewCallRequest("fail")).Get()
+	require.Error(t, err)
+
+	select {
+	case err := <-errs:
+		var tntErr Error
+		require.ErrorAs(t, err, &tntErr)
+		assert.Equal(t, iproto.ER_PROC_LUA, tntErr.Code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("interceptor has not observed the future")
+	}
+}
+
+func TestStream_Interceptors(t *testing.T) {
+	log := &interceptorLog{}
+	server, conn := mockserver.Connect(t, Opts{
+		Interceptors: []Interceptor{log.interceptor("stream", iproto.IPROTO_BEGIN)},
+	})
+
+	stream, err := conn.NewStream()
+	require.NoError(t, err)
+	_, err = stream.Do(NewBeginRequest()).Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"stream"}, log.get())
+	var begins []*mockserver.Request
+	for _, req := range server.Requests() {
+		if req.Type == iproto.IPROTO_BEGIN {
+			begins = append(begins, req)
+		}
+	}
+	require.Len(t, begins, 1)
+	assert.Equal(t, stream.Id, begins[0].StreamId)
+}
@@ -0,0 +1,227 @@
+package tarantool
+
+import (
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// LimitAlgorithm is an algorithm of an adaptive limit of in-flight
+// requests.
+type LimitAlgorithm int
+
+const (
+	// LimitAIMD increases the limit by one per a window of successful
+	// requests (additive increase) and multiplies it by
+	// AdaptiveLimit.BackoffRatio on a timeout or a too slow response
+	// (multiplicative decrease).
+	LimitAIMD LimitAlgorithm = iota
+	// LimitVegas estimates a queue size from the minimal observed latency
+	// and the current one. The limit is increased if the queue is small and
+	// decreased if it is large, as in TCP Vegas. It is multiplied by
+	// AdaptiveLimit.BackoffRatio on a timeout.
+	LimitVegas
+)
+
+const (
+	defaultAdaptiveInitialLimit = 20
+	defaultAdaptiveMinLimit     = 1
+	defaultAdaptiveMaxLimit     = 1000
+	defaultAdaptiveBackoffRatio = 0.9
+	defaultVegasAlpha           = 3
+	defaultVegasBeta            = 6
+)
+
+// AdaptiveLimit describes an adaptive limit of in-flight requests. The limit
+// is adjusted according to observed latencies and timeouts of requests. A
+// network timeout of the connection (see Opts.Timeout) is handled as a
+// timeout of a request.
+// When the limit is reached, Opts.RLimitAction is applied as for a static
+// Opts.RateLimit.
+type AdaptiveLimit struct {
+	// Algorithm is an algorithm to adjust the limit, LimitAIMD by default.
+	Algorithm LimitAlgorithm
+	// InitialLimit is a limit at the start, 20 by default.
+	InitialLimit uint
+	// MinLimit is a minimal limit, 1 by default.
+	MinLimit uint
+	// MaxLimit is a maximal limit, 1000 by default.
+	MaxLimit uint
+	// LatencyThreshold is a latency after which a response is considered
+	// as a sign of an overload by LimitAIMD. Only timeouts are taken into
+	// account if the value is zero.
+	LatencyThreshold time.Duration
+	// BackoffRatio is a multiplier of the limit on an overload, 0.9 by
+	// default.
+	BackoffRatio float64
+	// VegasAlpha is an estimated queue size below which LimitVegas
+	// increases the limit, 3 by default.
+	VegasAlpha uint
+	// VegasBeta is an estimated queue size above which LimitVegas
+	// decreases the limit, 6 by default.
+	VegasBeta uint
+}
+
+// adaptiveLimiter adjusts a number of available slots in a rate limit
+// channel. The channel capacity is the maximal limit, the limiter holds
+// slots in the channel to decrease the current limit.
+type adaptiveLimiter struct {
+	mutex  sync.Mutex
+	opts   AdaptiveLimit
+	rlimit chan struct{}
+	// limit is the current limit.
+	limit float64
+	// held is a number of slots held by the limiter.
+	held int
+	// minRTT is a minimal observed latency.
+	minRTT time.Duration
+}
+
+func newAdaptiveLimiter(opts AdaptiveLimit) (*adaptiveLimiter, error) {
+	if opts.MinLimit == 0 {
+		opts.MinLimit = defaultAdaptiveMinLimit
+	}
+	if opts.MaxLimit == 0 {
+		opts.MaxLimit = defaultAdaptiveMaxLimit
+	}
+	if opts.InitialLimit == 0 {
+		opts.InitialLimit = defaultAdaptiveInitialLimit
+	}
+	if opts.BackoffRatio == 0 {
+		opts.BackoffRatio = defaultAdaptiveBackoffRatio
+	}
+	if opts.VegasAlpha == 0 {
+		opts.VegasAlpha = defaultVegasAlpha
+	}
+	if opts.VegasBeta == 0 {
+		opts.VegasBeta = defaultVegasBeta
+	}
+
+	if opts.MinLimit > opts.MaxLimit {
+		return nil, errors.New("AdaptiveLimit.MinLimit should not be greater than MaxLimit")
+	}
+	if opts.BackoffRatio <= 0 || opts.BackoffRatio >= 1 {
+		return nil, errors.New("AdaptiveLimit.BackoffRatio should be in (0, 1)")
+	}
+	if opts.VegasAlpha >= opts.VegasBeta {
+		return nil, errors.New("AdaptiveLimit.VegasAlpha should be less than VegasBeta")
+	}
+	if opts.Algorithm != LimitAIMD && opts.Algorithm != LimitVegas {
+		return nil, errors.New("unknown AdaptiveLimit.Algorithm")
+	}
+
+	limit := opts.InitialLimit
+	if limit < opts.MinLimit {
+		limit = opts.MinLimit
+	}
+	if limit > opts.MaxLimit {
+		limit = opts.MaxLimit
+	}
+
+	limiter := &adaptiveLimiter{
+		opts:   opts,
+		rlimit: make(chan struct{}, opts.MaxLimit),
+		limit:  float64(limit),
+	}
+	limiter.adjust()
+	return limiter, nil
+}
+
+// Limit returns the current limit.
+func (l *adaptiveLimiter) Limit() uint {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return uint(l.limit)
+}
+
+// sample updates the limit according to a result of a request. It must be
+// called after a slot of the request is released.
+func (l *adaptiveLimiter) sample(rtt time.Duration, err error) {
+	var clientErr ClientError
+	timeout := errors.As(err, &clientErr) && clientErr.Code == ErrTimeouted
+	if err != nil && !timeout {
+		// Other errors say nothing about the load.
+		l.mutex.Lock()
+		l.adjust()
+		l.mutex.Unlock()
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if timeout {
+		l.limit *= l.opts.BackoffRatio
+	} else {
+		switch l.opts.Algorithm {
+		case LimitAIMD:
+			if l.opts.LatencyThreshold > 0 && rtt > l.opts.LatencyThreshold {
+				l.limit *= l.opts.BackoffRatio
+			} else {
+				l.limit += 1 / l.limit
+			}
+		case LimitVegas:
+			if l.minRTT == 0 || rtt < l.minRTT {
+				l.minRTT = rtt
+			}
+			if rtt > 0 {
+				queue := l.limit * (1 - float64(l.minRTT)/float64(rtt))
+				if queue < float64(l.opts.VegasAlpha) {
+					l.limit += 1 / l.limit
+				} else if queue > float64(l.opts.VegasBeta) {
+					l.limit -= 1 / l.limit
+				}
+			}
+		}
+	}
+	l.limit = math.Max(l.limit, float64(l.opts.MinLimit))
+	l.limit = math.Min(l.limit, float64(l.opts.MaxLimit))
+	l.adjust()
+}
+
+// overload decreases the limit on a network timeout of a connection.
+func (l *adaptiveLimiter) overload() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.limit = math.Max(l.limit*l.opts.BackoffRatio, float64(l.opts.MinLimit))
+	l.adjust()
+}
+
+// adjust holds or releases slots in the rate limit channel to match the
+// current limit. A slot could not be held if all slots are in use, it will
+// be held on a next call.
+func (l *adaptiveLimiter) adjust() {
+	target := cap(l.rlimit) - int(l.limit)
+	for l.held > target {
+		<-l.rlimit
+		l.held--
+	}
+	for l.held < target {
+		select {
+		case l.rlimit <- struct{}{}:
+			l.held++
+		default:
+			return
+		}
+	}
+}
+
+// ioFailed reports a network error of the connection to the adaptive
+// limiter and the circuit breaker. A network timeout breaks the connection
+// and requests fail with ErrIoError instead of ErrTimeouted, so the timeout
+// is a sign of an overload by itself.
+func (conn *Connection) ioFailed(err error) {
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		return
+	}
+	if conn.limiter != nil {
+		conn.limiter.overload()
+	}
+	if conn.breaker != nil {
+		conn.breaker.timedOut()
+	}
+}
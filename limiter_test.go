@@ -0,0 +1,179 @@
+package tarantool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func startLimiterServer(t *testing.T) *mockserver.Server {
+	t.Helper()
+
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("fast", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{true}, nil
+	})
+	server.HandleCall("slow", func(req *mockserver.Request) ([]interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return []interface{}{true}, nil
+	})
+	server.HandleCall("hang", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, mockserver.ErrNoResponse
+	})
+	return server
+}
+
+func callN(t *testing.T, conn *Connection, function string, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		_, err := conn.Do(NewCallRequest(function)).Get()
+		require.NoError(t, err)
+	}
+}
+
+func TestAdaptiveLimit_AIMD(t *testing.T) {
+	server := startLimiterServer(t)
+	conn := server.Connect(t, Opts{
+		RLimitAction: RLimitWait,
+		AdaptiveLimit: &AdaptiveLimit{
+			Algorithm:        LimitAIMD,
+			InitialLimit:     10,
+			MinLimit:         2,
+			MaxLimit:         20,
+			LatencyThreshold: 10 * time.Millisecond,
+			BackoffRatio:     0.5,
+		},
+	})
+	require.Equal(t, uint(10), conn.RateLimit())
+
+	// The limit grows by one per a window of successful requests.
+	callN(t, conn, "fast", 30)
+	assert.Equal(t, uint(12), conn.RateLimit())
+
+	callN(t, conn, "slow", 1)
+	assert.Equal(t, uint(6), conn.RateLimit())
+	callN(t, conn, "slow", 5)
+	assert.Equal(t, uint(2), conn.RateLimit())
+
+	callN(t, conn, "fast", 1000)
+	assert.Equal(t, uint(20), conn.RateLimit())
+}
+
+func TestAdaptiveLimit_Vegas(t *testing.T) {
+	server := startLimiterServer(t)
+	conn := server.Connect(t, Opts{
+		RLimitAction: RLimitWait,
+		AdaptiveLimit: &AdaptiveLimit{
+			Algorithm:    LimitVegas,
+			InitialLimit: 8,
+		},
+	})
+
+	// The minimal latency is observed with the fast responses.
+	callN(t, conn, "fast", 10)
+	limit := conn.RateLimit()
+
+	// The queue is estimated as large with the slow responses.
+	callN(t, conn, "slow", 20)
+	assert.Less(t, conn.RateLimit(), limit)
+}
+
+func TestAdaptiveLimit_timeout(t *testing.T) {
+	server := startLimiterServer(t)
+	conn := server.Connect(t, Opts{
+		Timeout:      50 * time.Millisecond,
+		RLimitAction: RLimitDrop,
+		AdaptiveLimit: &AdaptiveLimit{
+			InitialLimit: 4,
+			BackoffRatio: 0.5,
+		},
+	})
+
+	_, err := conn.Do(NewCallRequest("hang")).Get()
+	var clientErr ClientError
+	require.ErrorAs(t, err, &clientErr)
+	assert.EqualValues(t, ErrTimeouted, clientErr.Code)
+	assert.Equal(t, uint(2), conn.RateLimit())
+
+	// The current limit is applied with RLimitDrop.
+	futures := []*Future{
+		conn.Do(NewCallRequest("hang")),
+		conn.Do(NewCallRequest("hang")),
+	}
+	_, err = conn.Do(NewCallRequest("fast")).Get()
+	require.ErrorAs(t, err, &clientErr)
+	assert.EqualValues(t, ErrRateLimited, clientErr.Code)
+	for _, fut := range futures {
+		_, err := fut.Get()
+		assert.Error(t, err)
+	}
+	assert.Equal(t, uint(1), conn.RateLimit())
+}
+
+func TestAdaptiveLimit_ioTimeout(t *testing.T) {
+	server := startLimiterServer(t)
+	// The connection is broken by a read timeout before requests time out.
+	server.Handle(iproto.IPROTO_PING, func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, mockserver.ErrNoResponse
+	})
+	conn := server.Connect(t, Opts{
+		Timeout:      100 * time.Millisecond,
+		RLimitAction: RLimitWait,
+		AdaptiveLimit: &AdaptiveLimit{
+			InitialLimit: 8,
+			BackoffRatio: 0.5,
+		},
+	})
+
+	require.Eventually(t, conn.ClosedNow, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint(4), conn.RateLimit())
+}
+
+func TestAdaptiveLimit_opts(t *testing.T) {
+	server := startLimiterServer(t)
+
+	cases := []struct {
+		name string
+		opts Opts
+	}{
+		{"with rate limit", Opts{
+			RateLimit:     10,
+			RLimitAction:  RLimitWait,
+			AdaptiveLimit: &AdaptiveLimit{},
+		}},
+		{"no action", Opts{
+			RLimitAction:  RLimitAction(100),
+			AdaptiveLimit: &AdaptiveLimit{},
+		}},
+		{"min greater than max", Opts{
+			AdaptiveLimit: &AdaptiveLimit{MinLimit: 10, MaxLimit: 5},
+		}},
+		{"invalid backoff", Opts{
+			AdaptiveLimit: &AdaptiveLimit{BackoffRatio: 1.5},
+		}},
+		{"invalid algorithm", Opts{
+			AdaptiveLimit: &AdaptiveLimit{Algorithm: LimitAlgorithm(100)},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := Connect(ctx, NetDialer{Address: server.Addr()}, tc.opts)
+			assert.Error(t, err)
+		})
+	}
+
+	conn := server.Connect(t, Opts{RateLimit: 7, RLimitAction: RLimitWait})
+	assert.Equal(t, uint(7), conn.RateLimit())
+	conn = server.Connect(t, Opts{})
+	assert.Equal(t, uint(0), conn.RateLimit())
+}
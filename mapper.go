@@ -0,0 +1,315 @@
+package tarantool
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// mapperTag is a struct tag with a name of a space field.
+const mapperTag = "tnt"
+
+// Mapper encodes Go structs into tuples and decodes tuples into Go structs
+// by names of fields of a space format, so an order of fields in a struct
+// does not depend on an order of fields in the space.
+//
+// A struct field is mapped to a space field with a `tnt:"name"` tag. Fields
+// without the tag are ignored. The mapping is checked on the first use of a
+// struct type:
+//
+//	type User struct {
+//		Id    uint64 `tnt:"id"`
+//		Email string `tnt:"email"`
+//		Name  string `tnt:"name"`
+//	}
+//
+//	mapper, err := tarantool.NewMapper(schema.Spaces["users"])
+//	req := tarantool.NewInsertRequest("users").Tuple(mapper.Tuple(user))
+//
+//	var users []User
+//	err = conn.Do(tarantool.NewSelectRequest("users")).
+//		GetTyped(mapper.Tuples(&users))
+//
+// A tuple could not be encoded if a struct has no value for a non-nullable
+// field of the space. A tuple could not be decoded if it has no value for a
+// non-nullable field mapped to the struct. A value of a field is checked
+// against the field type on encoding, a decoding fails if the value could
+// not be decoded into the struct field.
+//
+// The Mapper is safe for concurrent use.
+type Mapper struct {
+	space Space
+	// mappings is a map of reflect.Type to *structMapping.
+	mappings sync.Map
+}
+
+// structMapping maps fields of a struct type to fields of a space.
+type structMapping struct {
+	// fields are indexes of struct fields by space field ids, -1 if a space
+	// field is not mapped.
+	fields []int
+	err    error
+}
+
+// NewMapper creates a new Mapper for the space. The space must have a
+// format, see Space.FieldsById.
+func NewMapper(space Space) (*Mapper, error) {
+	if len(space.FieldsById) == 0 {
+		return nil, fmt.Errorf("space %q has no format", space.Name)
+	}
+	for id := range space.FieldsById {
+		if int(id) >= len(space.FieldsById) {
+			return nil, fmt.Errorf("space %q has an invalid format", space.Name)
+		}
+	}
+	return &Mapper{space: space}, nil
+}
+
+// Tuple returns a value that encodes the struct as a tuple of the space. The
+// value could be used as a tuple of InsertRequest, ReplaceRequest and other
+// requests. v must be a struct or a pointer to a struct.
+func (m *Mapper) Tuple(v interface{}) interface{} {
+	return mappedTuple{mapper: m, value: v}
+}
+
+// Tuples returns a value that decodes response data into the structs. It
+// could be passed to Future.GetTyped() for responses of SelectRequest,
+// InsertRequest, ReplaceRequest and other requests that return tuples. v
+// must be a pointer to a slice of structs or pointers to structs.
+func (m *Mapper) Tuples(v interface{}) interface{} {
+	return &mappedTuples{mapper: m, value: v}
+}
+
+// Encode encodes the struct as a tuple of the space.
+func (m *Mapper) Encode(enc *msgpack.Encoder, v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return errors.New("unable to encode a nil struct")
+		}
+		value = value.Elem()
+	}
+	mapping, err := m.mapping(value.Type())
+	if err != nil {
+		return err
+	}
+
+	if err := enc.EncodeArrayLen(len(mapping.fields)); err != nil {
+		return err
+	}
+	for id, index := range mapping.fields {
+		field := m.space.FieldsById[uint32(id)]
+		var fieldValue reflect.Value
+		if index >= 0 {
+			fieldValue = value.Field(index)
+			for fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Interface {
+				if fieldValue.IsNil() {
+					break
+				}
+				fieldValue = fieldValue.Elem()
+			}
+		}
+
+		if !fieldValue.IsValid() || isNilValue(fieldValue) {
+			if !field.IsNullable {
+				return fmt.Errorf("missing value for non-nullable field %q", field.Name)
+			}
+			if err := enc.EncodeNil(); err != nil {
+				return err
+			}
+			continue
+		}
+		if !fieldTypeMatches(field.Type, fieldValue) {
+			return fmt.Errorf("field %q of type %s could not be encoded from %s",
+				field.Name, field.Type, fieldValue.Type())
+		}
+		if err := enc.EncodeValue(fieldValue); err != nil {
+			return fmt.Errorf("failed to encode field %q: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// Decode decodes a tuple of the space into the struct. v must be a pointer
+// to a struct.
+func (m *Mapper) Decode(d *msgpack.Decoder, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("a non-nil pointer to a struct is expected")
+	}
+	return m.decodeValue(d, value.Elem())
+}
+
+func (m *Mapper) decodeValue(d *msgpack.Decoder, value reflect.Value) error {
+	mapping, err := m.mapping(value.Type())
+	if err != nil {
+		return err
+	}
+
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	for id := 0; id < l; id++ {
+		if id >= len(mapping.fields) || mapping.fields[id] < 0 {
+			if err := d.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		field := m.space.FieldsById[uint32(id)]
+		if err := d.DecodeValue(value.Field(mapping.fields[id])); err != nil {
+			return fmt.Errorf("failed to decode field %q of type %s: %w",
+				field.Name, field.Type, err)
+		}
+	}
+	for id := l; id < len(mapping.fields); id++ {
+		field := m.space.FieldsById[uint32(id)]
+		if mapping.fields[id] >= 0 && !field.IsNullable {
+			return fmt.Errorf("missing value for non-nullable field %q", field.Name)
+		}
+	}
+	return nil
+}
+
+// mapping returns a mapping for the struct type.
+func (m *Mapper) mapping(typ reflect.Type) (*structMapping, error) {
+	if cached, ok := m.mappings.Load(typ); ok {
+		mapping := cached.(*structMapping)
+		return mapping, mapping.err
+	}
+
+	mapping := m.newMapping(typ)
+	m.mappings.Store(typ, mapping)
+	return mapping, mapping.err
+}
+
+func (m *Mapper) newMapping(typ reflect.Type) *structMapping {
+	mapping := &structMapping{fields: make([]int, len(m.space.FieldsById))}
+	for i := range mapping.fields {
+		mapping.fields[i] = -1
+	}
+	if typ.Kind() != reflect.Struct {
+		mapping.err = fmt.Errorf("a struct is expected, got %s", typ)
+		return mapping
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		name, ok := structField.Tag.Lookup(mapperTag)
+		if !ok || name == "-" {
+			continue
+		}
+		if !structField.IsExported() {
+			mapping.err = fmt.Errorf("field %s of %s is not exported",
+				structField.Name, typ)
+			return mapping
+		}
+		field, ok := m.space.Fields[name]
+		if !ok {
+			mapping.err = fmt.Errorf("space %q has no field %q for %s.%s",
+				m.space.Name, name, typ, structField.Name)
+			return mapping
+		}
+		if mapping.fields[field.Id] >= 0 {
+			mapping.err = fmt.Errorf("field %q is mapped twice in %s", name, typ)
+			return mapping
+		}
+		mapping.fields[field.Id] = i
+	}
+	return mapping
+}
+
+// isNilValue returns true if the value is a nil pointer, interface, map or
+// slice.
+func isNilValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return value.IsNil()
+	}
+	return false
+}
+
+// fieldTypeMatches returns true if the value could be stored in a field of
+// the type. Types without a direct Go analogue are not checked.
+func fieldTypeMatches(fieldType string, value reflect.Value) bool {
+	kind := value.Kind()
+	isInt := kind >= reflect.Int && kind <= reflect.Int64
+	isUint := kind >= reflect.Uint && kind <= reflect.Uintptr
+	isFloat := kind == reflect.Float32 || kind == reflect.Float64
+	isBytes := (kind == reflect.Slice || kind == reflect.Array) &&
+		value.Type().Elem().Kind() == reflect.Uint8
+
+	switch fieldType {
+	case "unsigned":
+		return isUint || (isInt && value.Int() >= 0)
+	case "integer":
+		return isInt || isUint
+	case "number":
+		return isInt || isUint || isFloat
+	case "double":
+		return isFloat
+	case "string":
+		return kind == reflect.String
+	case "boolean":
+		return kind == reflect.Bool
+	case "varbinary":
+		return isBytes
+	case "array":
+		return (kind == reflect.Slice || kind == reflect.Array) && !isBytes
+	case "map":
+		return kind == reflect.Map || kind == reflect.Struct
+	}
+	return true
+}
+
+// mappedTuple encodes a struct as a tuple with a Mapper.
+type mappedTuple struct {
+	mapper *Mapper
+	value  interface{}
+}
+
+// EncodeMsgpack encodes the struct as a tuple.
+func (t mappedTuple) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return t.mapper.Encode(enc, t.value)
+}
+
+// mappedTuples decodes tuples into a slice of structs with a Mapper.
+type mappedTuples struct {
+	mapper *Mapper
+	value  interface{}
+}
+
+// DecodeMsgpack decodes an array of tuples into the slice.
+func (t *mappedTuples) DecodeMsgpack(d *msgpack.Decoder) error {
+	value := reflect.ValueOf(t.value)
+	if value.Kind() != reflect.Ptr || value.IsNil() ||
+		value.Elem().Kind() != reflect.Slice {
+		return errors.New("a non-nil pointer to a slice is expected")
+	}
+	slice := value.Elem()
+	elemType := slice.Type().Elem()
+
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	result := reflect.MakeSlice(slice.Type(), 0, l)
+	for i := 0; i < l; i++ {
+		elem := reflect.New(elemType).Elem()
+		target := elem
+		if elemType.Kind() == reflect.Ptr {
+			elem.Set(reflect.New(elemType.Elem()))
+			target = elem.Elem()
+		}
+		if err := t.mapper.decodeValue(d, target); err != nil {
+			return err
+		}
+		result = reflect.Append(result, elem)
+	}
+	slice.Set(result)
+	return nil
+}
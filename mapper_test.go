@@ -0,0 +1,193 @@
+package tarantool_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+	"github.com/vmihailenco/msgpack/v5"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+type mapperUser struct {
+	Name   string  `tnt:"name"`
+	Id     uint64  `tnt:"id"`
+	Email  *string `tnt:"email"`
+	Ignore string
+}
+
+func mapperSpace() Space {
+	fields := []Field{
+		{Id: 0, Name: "id", Type: "unsigned"},
+		{Id: 1, Name: "name", Type: "string"},
+		{Id: 2, Name: "age", Type: "unsigned", IsNullable: true},
+		{Id: 3, Name: "email", Type: "string", IsNullable: true},
+	}
+	space := Space{
+		Id:         512,
+		Name:       "users",
+		Fields:     make(map[string]Field),
+		FieldsById: make(map[uint32]Field),
+	}
+	for _, field := range fields {
+		space.Fields[field.Name] = field
+		space.FieldsById[field.Id] = field
+	}
+	return space
+}
+
+func newMapper(t *testing.T) *Mapper {
+	t.Helper()
+
+	mapper, err := NewMapper(mapperSpace())
+	require.NoError(t, err)
+	return mapper
+}
+
+func encodeMapped(mapper *Mapper, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := msgpack.NewEncoder(&buf).Encode(mapper.Tuple(v))
+	return buf.Bytes(), err
+}
+
+func TestNewMapper_noFormat(t *testing.T) {
+	_, err := NewMapper(Space{Name: "users"})
+	assert.EqualError(t, err, `space "users" has no format`)
+}
+
+func TestMapper_roundTrip(t *testing.T) {
+	mapper := newMapper(t)
+
+	email := "alice@example.com"
+	data, err := encodeMapped(mapper, &mapperUser{
+		Name:   "alice",
+		Id:     1,
+		Email:  &email,
+		Ignore: "ignored",
+	})
+	require.NoError(t, err)
+
+	var tuple []interface{}
+	require.NoError(t, msgpack.Unmarshal(data, &tuple))
+	assert.Equal(t, []interface{}{uint64(1), "alice", nil, email}, tuple)
+
+	var user mapperUser
+	require.NoError(t, mapper.Decode(msgpack.NewDecoder(bytes.NewReader(data)), &user))
+	assert.Equal(t, mapperUser{Name: "alice", Id: 1, Email: &email}, user)
+}
+
+func TestMapper_nullable(t *testing.T) {
+	mapper := newMapper(t)
+
+	data, err := encodeMapped(mapper, mapperUser{Name: "bob", Id: 2})
+	require.NoError(t, err)
+
+	var tuple []interface{}
+	require.NoError(t, msgpack.Unmarshal(data, &tuple))
+	assert.Equal(t, []interface{}{uint64(2), "bob", nil, nil}, tuple)
+
+	// Trailing nullable fields could be omitted in a tuple.
+	data, err = msgpack.Marshal([]interface{}{2, "bob"})
+	require.NoError(t, err)
+
+	var user mapperUser
+	require.NoError(t, mapper.Decode(msgpack.NewDecoder(bytes.NewReader(data)), &user))
+	assert.Equal(t, mapperUser{Name: "bob", Id: 2}, user)
+}
+
+func TestMapper_missingField(t *testing.T) {
+	mapper := newMapper(t)
+
+	type noName struct {
+		Id uint64 `tnt:"id"`
+	}
+	_, err := encodeMapped(mapper, noName{Id: 1})
+	assert.EqualError(t, err, `missing value for non-nullable field "name"`)
+
+	data, err := msgpack.Marshal([]interface{}{1})
+	require.NoError(t, err)
+
+	var user mapperUser
+	err = mapper.Decode(msgpack.NewDecoder(bytes.NewReader(data)), &user)
+	assert.EqualError(t, err, `missing value for non-nullable field "name"`)
+}
+
+func TestMapper_unknownField(t *testing.T) {
+	mapper := newMapper(t)
+
+	type unknown struct {
+		Id    uint64 `tnt:"id"`
+		Phone string `tnt:"phone"`
+	}
+	_, err := encodeMapped(mapper, unknown{})
+	assert.ErrorContains(t, err, `space "users" has no field "phone"`)
+}
+
+func TestMapper_typeMismatch(t *testing.T) {
+	mapper := newMapper(t)
+
+	type wrongEncode struct {
+		Id   int64  `tnt:"id"`
+		Name string `tnt:"name"`
+	}
+	_, err := encodeMapped(mapper, wrongEncode{Id: -1, Name: "alice"})
+	assert.EqualError(t, err,
+		`field "id" of type unsigned could not be encoded from int64`)
+
+	type wrongDecode struct {
+		Id   uint64 `tnt:"id"`
+		Name bool   `tnt:"name"`
+	}
+	data, err := msgpack.Marshal([]interface{}{1, "alice"})
+	require.NoError(t, err)
+
+	var v wrongDecode
+	err = mapper.Decode(msgpack.NewDecoder(bytes.NewReader(data)), &v)
+	assert.ErrorContains(t, err, `failed to decode field "name" of type string`)
+}
+
+func TestMapper_requests(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	echo := func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{req.Tuple()}, nil
+	}
+	server.Handle(iproto.IPROTO_INSERT, echo)
+	server.Handle(iproto.IPROTO_REPLACE, echo)
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{
+			[]interface{}{1, "alice", 30, "alice@example.com"},
+			[]interface{}{2, "bob", nil, nil},
+		}, nil
+	})
+
+	conn := server.Connect(t, Opts{})
+
+	mapper := newMapper(t)
+	user := mapperUser{Name: "alice", Id: 1}
+
+	var inserted []mapperUser
+	err := conn.Do(NewInsertRequest(512).Tuple(mapper.Tuple(user))).
+		GetTyped(mapper.Tuples(&inserted))
+	require.NoError(t, err)
+	assert.Equal(t, []mapperUser{user}, inserted)
+
+	var replaced []*mapperUser
+	err = conn.Do(NewReplaceRequest(512).Tuple(mapper.Tuple(&user))).
+		GetTyped(mapper.Tuples(&replaced))
+	require.NoError(t, err)
+	assert.Equal(t, []*mapperUser{&user}, replaced)
+
+	var selected []mapperUser
+	err = conn.Do(NewSelectRequest(512).Index(0)).GetTyped(mapper.Tuples(&selected))
+	require.NoError(t, err)
+	email := "alice@example.com"
+	assert.Equal(t, []mapperUser{
+		{Name: "alice", Id: 1, Email: &email},
+		{Name: "bob", Id: 2},
+	}, selected)
+}
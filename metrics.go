@@ -0,0 +1,65 @@
+package tarantool
+
+import (
+	"time"
+
+	"github.com/tarantool/go-iproto"
+)
+
+// Metrics is the interface to collect metrics of a Connection. It could be
+// set with Opts.Metrics.
+//
+// Methods are called synchronously from internal goroutines of the
+// connection, sometimes with internal locks held, so they should be fast,
+// non-blocking and safe for concurrent use.
+type Metrics interface {
+	// RequestStarted is called when a request is passed to the connection.
+	// It increases the count of requests in flight.
+	RequestStarted(conn *Connection, rtype iproto.Type)
+	// RequestDone is called when a request has been completed: a response
+	// is received, the request is failed, canceled or timed out. It
+	// decreases the count of requests in flight.
+	//
+	// The err is nil on success. Client-side errors are passed as is, for
+	// example, ClientError with ErrTimeouted code on a timeout. An error
+	// response from Tarantool is passed as Error with the error code only,
+	// without decoding of the response body.
+	RequestDone(conn *Connection, rtype iproto.Type, elapsed time.Duration, err error)
+	// ConnectionEvent is called on every connection event, the same as sent
+	// to Opts.Notify.
+	ConnectionEvent(conn *Connection, kind ConnEventKind)
+	// BytesRead is called when a packet has been read from the network.
+	BytesRead(conn *Connection, n int)
+	// BytesWritten is called when data has been written to the network.
+	BytesWritten(conn *Connection, n int)
+}
+
+// requestStarted reports a started request to the metrics.
+func (conn *Connection) requestStarted(fut *Future) {
+	if conn.opts.Metrics == nil && conn.limiter == nil {
+		return
+	}
+	// The start time is used by the adaptive limiter too.
+	fut.start = time.Now()
+	if conn.opts.Metrics != nil {
+		conn.opts.Metrics.RequestStarted(conn, fut.req.Type())
+	}
+}
+
+// requestDone reports a completed request to the metrics.
+func (conn *Connection) requestDone(fut *Future) {
+	if conn.opts.Metrics == nil {
+		return
+	}
+
+	fut.mutex.Lock()
+	err := fut.err
+	if err == nil && fut.resp != nil {
+		if header := fut.resp.Header(); header.Error != ErrorNo {
+			err = Error{Code: header.Error}
+		}
+	}
+	fut.mutex.Unlock()
+
+	conn.opts.Metrics.RequestDone(conn, fut.req.Type(), time.Since(fut.start), err)
+}
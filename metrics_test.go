@@ -0,0 +1,100 @@
+package tarantool_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+type mockMetrics struct {
+	mutex        sync.Mutex
+	started      map[iproto.Type]int
+	done         map[iproto.Type]int
+	errors       []error
+	events       []ConnEventKind
+	bytesRead    int
+	bytesWritten int
+}
+
+func newMockMetrics() *mockMetrics {
+	return &mockMetrics{
+		started: make(map[iproto.Type]int),
+		done:    make(map[iproto.Type]int),
+	}
+}
+
+func (m *mockMetrics) RequestStarted(conn *Connection, rtype iproto.Type) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.started[rtype]++
+}
+
+func (m *mockMetrics) RequestDone(conn *Connection, rtype iproto.Type,
+	elapsed time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.done[rtype]++
+	if err != nil {
+		m.errors = append(m.errors, err)
+	}
+}
+
+func (m *mockMetrics) ConnectionEvent(conn *Connection, kind ConnEventKind) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.events = append(m.events, kind)
+}
+
+func (m *mockMetrics) BytesRead(conn *Connection, n int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.bytesRead += n
+}
+
+func (m *mockMetrics) BytesWritten(conn *Connection, n int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.bytesWritten += n
+}
+
+func TestConnection_Metrics(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("fail", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, Error{Code: iproto.ER_PROC_LUA, Msg: "fail"}
+	})
+
+	metrics := newMockMetrics()
+	conn := server.Connect(t, Opts{Metrics: metrics})
+
+	_, err := conn.Do(NewPingRequest()).Get()
+	require.NoError(t, err)
+	_, err = conn.Do(NewCallRequest("fail")).Get()
+	require.Error(t, err)
+	conn.Close()
+
+	_, err = conn.Do(NewPingRequest()).Get()
+	require.Error(t, err)
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	assert.Equal(t, metrics.started, metrics.done)
+	assert.GreaterOrEqual(t, metrics.started[iproto.IPROTO_PING], 2)
+	assert.Equal(t, 1, metrics.started[iproto.IPROTO_CALL])
+	require.Len(t, metrics.errors, 2)
+	assert.Equal(t, Error{Code: iproto.ER_PROC_LUA}, metrics.errors[0])
+	assert.ErrorIs(t, metrics.errors[1], ClientError{
+		Code: ErrConnectionClosed,
+		Msg:  "using closed connection",
+	})
+	assert.Equal(t, []ConnEventKind{Connected, Closed}, metrics.events)
+	assert.Greater(t, metrics.bytesRead, 0)
+	assert.Greater(t, metrics.bytesWritten, 0)
+}
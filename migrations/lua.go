@@ -0,0 +1,229 @@
+package migrations
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const createSpaceLua = `local name, opts = ...
+box.schema.space.create(name, opts)
+`
+
+const setFormatLua = `local name, format = ...
+local space = box.space[name]
+if space == nil then
+    error(string.format("space %s does not exist", name))
+end
+space:format(format)
+`
+
+const dropSpaceLua = `local name, if_exists = ...
+local space = box.space[name]
+if space == nil then
+    if if_exists then
+        return
+    end
+    error(string.format("space %s does not exist", name))
+end
+space:drop()
+`
+
+const createIndexLua = `local name, index, opts = ...
+local space = box.space[name]
+if space == nil then
+    error(string.format("space %s does not exist", name))
+end
+space:create_index(index, opts)
+`
+
+const alterIndexLua = `local name, index, opts = ...
+local space = box.space[name]
+if space == nil or space.index[index] == nil then
+    error(string.format("index %s of space %s does not exist", index, name))
+end
+space.index[index]:alter(opts)
+`
+
+const dropIndexLua = `local name, index, if_exists = ...
+local space = box.space[name]
+if space == nil or space.index[index] == nil then
+    if if_exists then
+        return
+    end
+    error(string.format("index %s of space %s does not exist", index, name))
+end
+space.index[index]:drop()
+`
+
+// initLua creates the service space and the lock space.
+const initLua = `local name = ...
+box.schema.space.create(name, {
+    if_not_exists = true,
+    format = {
+        {name = 'version', type = 'unsigned'},
+        {name = 'name', type = 'string'},
+        {name = 'applied_at', type = 'number'},
+    },
+})
+box.space[name]:create_index('primary', {
+    parts = {{1, 'unsigned'}},
+    if_not_exists = true,
+})
+box.schema.space.create(name .. '_lock', {
+    if_not_exists = true,
+    format = {
+        {name = 'name', type = 'string'},
+        {name = 'owner', type = 'string'},
+        {name = 'expires', type = 'number'},
+    },
+})
+box.space[name .. '_lock']:create_index('primary', {
+    parts = {{1, 'string'}},
+    if_not_exists = true,
+})
+`
+
+// appliedLua returns applied migrations from the service space.
+const appliedLua = `local space = box.space[...]
+local applied = {}
+if space ~= nil then
+    for _, t in space:pairs() do
+        table.insert(applied, {version = t[1], name = t[2], applied_at = t[3]})
+    end
+end
+return applied
+`
+
+// lockLua takes the lock and returns nil or returns an owner of the lock.
+const lockLua = `local name, owner, ttl = ...
+local lock = box.space[name .. '_lock']
+return box.atomic(function()
+    local now = require('fiber').time()
+    local t = lock:get('lock')
+    if t ~= nil and t[2] ~= owner and t[3] > now then
+        return t[2]
+    end
+    lock:replace({'lock', owner, now + ttl})
+    return nil
+end)
+`
+
+const unlockLua = `local name, owner = ...
+local lock = box.space[name .. '_lock']
+local t = lock:get('lock')
+if t ~= nil and t[2] == owner then
+    lock:delete('lock')
+end
+`
+
+// migrateLua executes steps of a migration and records the result in the
+// service space. An error of a step contains a number of the step, previous
+// steps stay executed.
+const migrateLua = `local steps, name, version, migration, up = ...
+for i, step in ipairs(steps) do
+    local chunk, err = loadstring(step[1])
+    if chunk == nil then
+        error(string.format("step %d: %s", i, err))
+    end
+    local ok, err = pcall(chunk, unpack(step[2]))
+    if not ok then
+        error(string.format("step %d: %s", i, err))
+    end
+end
+if up then
+    box.space[name]:insert({version, migration, require('fiber').time()})
+else
+    box.space[name]:delete({version})
+end
+`
+
+var luaIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// luaArgs formats arguments of a step as Lua values.
+func luaArgs(args []interface{}) string {
+	data, err := msgpack.Marshal(args)
+	if err != nil {
+		return fmt.Sprintf("%v", args)
+	}
+	var values []interface{}
+	if err := msgpack.Unmarshal(data, &values); err != nil {
+		return fmt.Sprintf("%v", args)
+	}
+
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, luaValue(value))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// luaValue formats a decoded msgpack value as a Lua value.
+func luaValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return luaQuote(value)
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, luaValue(item))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}:
+		items := make([]string, 0, len(value))
+		for _, key := range sortedKeys(value) {
+			items = append(items, luaKey(key)+" = "+luaValue(value[key]))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case map[interface{}]interface{}:
+		items := make([]string, 0, len(value))
+		for key, item := range value {
+			items = append(items, "["+luaValue(key)+"] = "+luaValue(item))
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func luaKey(key string) string {
+	if luaIdentifier.MatchString(key) {
+		return key
+	}
+	return "[" + luaQuote(key) + "]"
+}
+
+// luaQuote returns a Lua string literal of the string. Unlike
+// strconv.Quote(), it uses escapes of Lua 5.1: non-printable and non-ASCII
+// bytes are escaped with decimal \ddd.
+func luaQuote(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < ' ' || c > '~' {
+				fmt.Fprintf(&b, `\%03d`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
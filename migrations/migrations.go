@@ -0,0 +1,434 @@
+// Package migrations applies versioned schema migrations declared in Go.
+//
+// A migration consists of steps: declarative steps like CreateSpace,
+// CreateIndex or AlterIndex and Lua chunks. Migrations are applied in
+// ascending order of versions, applied versions are recorded in a service
+// space:
+//
+//	migrator, err := migrations.New(conn, []migrations.Migration{
+//		{
+//			Version: 1,
+//			Name:    "create users",
+//			Up: []migrations.Step{
+//				migrations.CreateSpace{
+//					Name: "users",
+//					Format: []box.SpaceField{
+//						{Name: "id", Type: "unsigned"},
+//						{Name: "email", Type: "string"},
+//					},
+//				},
+//				migrations.CreateIndex{
+//					Space:  "users",
+//					Name:   "primary",
+//					Unique: true,
+//					Parts:  []box.IndexPart{{Field: "id"}},
+//				},
+//			},
+//			Down: []migrations.Step{
+//				migrations.DropSpace{Name: "users"},
+//			},
+//		},
+//	}, migrations.Opts{})
+//	applied, err := migrator.Up(ctx, 0)
+//
+// Only one Migrator could migrate an instance at a time: Up() and Down()
+// take a lock stored in the "<service space>_lock" space. After migrations
+// the Migrator loads a schema with tarantool.GetSchema() and verifies that
+// spaces, formats and indexes declared by steps match the schema.
+//
+// Steps are executed with EvalRequest, so a user needs the 'execute
+// universe' privilege. Migrations should be applied on a master instance.
+// Steps of a migration are not executed in a transaction, see Migrator.Up()
+// to recover from a failed step.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+const (
+	defaultSpace   = "schema_migrations"
+	defaultLockTTL = 10 * time.Minute
+)
+
+// ErrLocked is returned if the lock is taken by another Migrator.
+var ErrLocked = errors.New("migrations are locked")
+
+// Migration is a versioned change of a schema.
+type Migration struct {
+	// Version is a positive unique version of the migration.
+	Version uint64
+	// Name is a description of the migration.
+	Name string
+	// Up are steps to apply the migration.
+	Up []Step
+	// Down are steps to roll back the migration. A migration without Down
+	// steps could not be rolled back.
+	Down []Step
+}
+
+// AppliedMigration is a migration recorded in the service space.
+type AppliedMigration struct {
+	Version   uint64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Direction is a direction of a migration.
+type Direction int
+
+const (
+	// DirectionUp means that a migration is applied.
+	DirectionUp Direction = iota
+	// DirectionDown means that a migration is rolled back.
+	DirectionDown
+)
+
+// String returns a name of the direction.
+func (d Direction) String() string {
+	if d == DirectionDown {
+		return "down"
+	}
+	return "up"
+}
+
+// PlannedMigration is a migration to apply or to roll back.
+type PlannedMigration struct {
+	Migration
+	Direction Direction
+}
+
+// Steps returns steps to execute.
+func (m PlannedMigration) Steps() []Step {
+	if m.Direction == DirectionDown {
+		return m.Down
+	}
+	return m.Up
+}
+
+// Plan is a list of migrations to execute in order.
+type Plan []PlannedMigration
+
+// String returns Lua chunks of steps of the plan. It could be used as a
+// dry-run output.
+func (p Plan) String() string {
+	var b strings.Builder
+	for _, migration := range p {
+		fmt.Fprintf(&b, "-- %s %d %q\n", migration.Direction, migration.Version,
+			migration.Name)
+		for i, step := range migration.Steps() {
+			code, args := step.Lua()
+			fmt.Fprintf(&b, "-- step %d: ... = %s\n", i+1, luaArgs(args))
+			b.WriteString(code)
+			if !strings.HasSuffix(code, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// Opts are options of a Migrator.
+type Opts struct {
+	// Space is a name of the service space with applied migrations. It is
+	// "schema_migrations" by default.
+	Space string
+	// Owner is a name of the Migrator in the lock. It is "<hostname>:<pid>"
+	// by default.
+	Owner string
+	// LockTTL is a period of time after that the lock taken by a crashed
+	// Migrator expires. It is 10 minutes by default.
+	LockTTL time.Duration
+	// DryRun makes Up() and Down() return a plan without changes.
+	DryRun bool
+	// SkipVerify disables a schema verification after migrations.
+	SkipVerify bool
+}
+
+// Migrator applies and rolls back migrations.
+type Migrator struct {
+	doer       tarantool.Doer
+	migrations []Migration
+	opts       Opts
+}
+
+// New creates a new Migrator. Migrations could be passed in any order.
+func New(doer tarantool.Doer, migrations []Migration, opts Opts) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i, migration := range sorted {
+		if migration.Version == 0 {
+			return nil, errors.New("migration version must be positive")
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+
+	if opts.Space == "" {
+		opts.Space = defaultSpace
+	}
+	if opts.Owner == "" {
+		hostname, _ := os.Hostname()
+		opts.Owner = fmt.Sprintf("%s:%d", hostname, os.Getpid())
+	}
+	if opts.LockTTL == 0 {
+		opts.LockTTL = defaultLockTTL
+	}
+
+	return &Migrator{
+		doer:       doer,
+		migrations: sorted,
+		opts:       opts,
+	}, nil
+}
+
+// Applied returns migrations recorded in the service space in ascending
+// order of versions.
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	var result []struct {
+		Version   uint64  `msgpack:"version"`
+		Name      string  `msgpack:"name"`
+		AppliedAt float64 `msgpack:"applied_at"`
+	}
+	if err := m.eval(ctx, appliedLua, &[]interface{}{&result}, m.opts.Space); err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	applied := make([]AppliedMigration, 0, len(result))
+	for _, migration := range result {
+		sec, frac := math.Modf(migration.AppliedAt)
+		applied = append(applied, AppliedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Unix(int64(sec), int64(frac*float64(time.Second))),
+		})
+	}
+	sort.Slice(applied, func(i, j int) bool {
+		return applied[i].Version < applied[j].Version
+	})
+	return applied, nil
+}
+
+// Up applies migrations with versions up to the target version. Zero target
+// means the latest version. It returns applied migrations or a plan if
+// Opts.DryRun is set.
+//
+// If a step of a migration fails, it returns migrations applied before.
+// Changes of previous steps of the failed migration are not rolled back and
+// the migration is not recorded, because DDL could not be executed in a
+// single transaction. The changes should be reverted manually before the
+// next Up(). Alternatively, steps could be written to be executed again:
+// with IfNotExists and IfExists options and idempotent Lua chunks.
+func (m *Migrator) Up(ctx context.Context, target uint64) (Plan, error) {
+	if target == 0 && len(m.migrations) > 0 {
+		target = m.migrations[len(m.migrations)-1].Version
+	}
+	// Zero target is left without migrations, the plan is empty.
+	if target != 0 {
+		if err := m.checkTarget(target); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.migrate(ctx, func(applied map[uint64]bool) (Plan, error) {
+		var maxApplied uint64
+		for version := range applied {
+			if version > maxApplied {
+				maxApplied = version
+			}
+		}
+
+		var plan Plan
+		for _, migration := range m.migrations {
+			if migration.Version > target {
+				break
+			}
+			if applied[migration.Version] {
+				continue
+			}
+			if migration.Version < maxApplied {
+				return nil, fmt.Errorf("migration %d is older than the applied migration %d",
+					migration.Version, maxApplied)
+			}
+			plan = append(plan, PlannedMigration{Migration: migration})
+		}
+		return plan, nil
+	}, target)
+}
+
+// Down rolls back applied migrations with versions greater than the target
+// version in descending order. Zero target means that all migrations are
+// rolled back. It returns rolled back migrations or a plan if Opts.DryRun is
+// set.
+func (m *Migrator) Down(ctx context.Context, target uint64) (Plan, error) {
+	if target != 0 {
+		if err := m.checkTarget(target); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.migrate(ctx, func(applied map[uint64]bool) (Plan, error) {
+		var plan Plan
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version <= target {
+				break
+			}
+			if !applied[migration.Version] {
+				continue
+			}
+			if len(migration.Down) == 0 {
+				return nil, fmt.Errorf("migration %d could not be rolled back",
+					migration.Version)
+			}
+			plan = append(plan, PlannedMigration{
+				Migration: migration,
+				Direction: DirectionDown,
+			})
+		}
+		return plan, nil
+	}, target)
+}
+
+func (m *Migrator) checkTarget(target uint64) error {
+	for _, migration := range m.migrations {
+		if migration.Version == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown migration version %d", target)
+}
+
+// migrate creates a plan and executes it under the lock.
+func (m *Migrator) migrate(ctx context.Context,
+	planFunc func(applied map[uint64]bool) (Plan, error), target uint64) (Plan, error) {
+	if m.opts.DryRun {
+		applied, err := m.appliedVersions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return planFunc(applied)
+	}
+
+	if err := m.eval(ctx, initLua, nil, m.opts.Space); err != nil {
+		return nil, fmt.Errorf("failed to create the service space: %w", err)
+	}
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planFunc(applied)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expected state is built from the full history.
+	state := &schemaState{spaces: make(map[string]*spaceState)}
+	for _, migration := range m.migrations {
+		if applied[migration.Version] || migration.Version <= target {
+			state.apply(migration.Up)
+		}
+	}
+
+	for i, migration := range plan {
+		if err := m.execute(ctx, migration); err != nil {
+			return plan[:i], fmt.Errorf("failed to %s migration %d %q: %w",
+				migration.Direction, migration.Version, migration.Name, err)
+		}
+		if migration.Direction == DirectionDown {
+			state.apply(migration.Down)
+		}
+	}
+
+	if !m.opts.SkipVerify {
+		schema, err := tarantool.GetSchema(m.doer)
+		if err != nil {
+			return plan, fmt.Errorf("failed to get schema: %w", err)
+		}
+		if err := state.verify(schema); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
+
+// appliedVersions returns a set of applied versions. All of them must be
+// known.
+func (m *Migrator) appliedVersions(ctx context.Context) (map[uint64]bool, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[uint64]bool, len(applied))
+	for _, migration := range applied {
+		if err := m.checkTarget(migration.Version); err != nil {
+			return nil, fmt.Errorf("applied migration %d %q is unknown",
+				migration.Version, migration.Name)
+		}
+		versions[migration.Version] = true
+	}
+	return versions, nil
+}
+
+// execute executes steps of the migration and records it in the service
+// space with a single request.
+func (m *Migrator) execute(ctx context.Context, migration PlannedMigration) error {
+	steps := make([]interface{}, 0, len(migration.Steps()))
+	for _, step := range migration.Steps() {
+		code, args := step.Lua()
+		if args == nil {
+			args = []interface{}{}
+		}
+		steps = append(steps, []interface{}{code, args})
+	}
+	return m.eval(ctx, migrateLua, nil, steps, m.opts.Space, migration.Version,
+		migration.Name, migration.Direction == DirectionUp)
+}
+
+func (m *Migrator) lock(ctx context.Context) error {
+	var owner []*string
+	err := m.eval(ctx, lockLua, &owner, m.opts.Space, m.opts.Owner,
+		m.opts.LockTTL.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to take the lock: %w", err)
+	}
+	if len(owner) > 0 && owner[0] != nil {
+		return fmt.Errorf("%w by %s", ErrLocked, *owner[0])
+	}
+	return nil
+}
+
+func (m *Migrator) unlock() {
+	// The lock expires anyway if the request fails.
+	_ = m.eval(context.Background(), unlockLua, nil, m.opts.Space, m.opts.Owner)
+}
+
+func (m *Migrator) eval(ctx context.Context, expr string, result interface{},
+	args ...interface{}) error {
+	req := tarantool.NewEvalRequest(expr).Args(args).Context(ctx)
+	fut := m.doer.Do(req)
+	if result == nil {
+		_, err := fut.Get()
+		return err
+	}
+	return fut.GetTyped(result)
+}
@@ -0,0 +1,429 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+	"github.com/tarantool/go-tarantool/v2/migrations"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+// fakeSpace is a space of fakeInstance.
+type fakeSpace struct {
+	id      uint32
+	format  []interface{}
+	indexes []fakeIndex
+}
+
+type fakeIndex struct {
+	name   string
+	typ    string
+	unique bool
+	parts  []interface{}
+}
+
+// fakeInstance is an in-memory Tarantool instance that executes
+// declarative steps of migrations.
+type fakeInstance struct {
+	*mockserver.Server
+
+	mutex     sync.Mutex
+	spaces    map[string]*fakeSpace
+	nextId    uint32
+	applied   map[uint64]string
+	lockOwner string
+	// steps are codes of executed steps.
+	steps   []string
+	migrate int
+}
+
+func stepCode(step migrations.Step) string {
+	code, _ := step.Lua()
+	return code
+}
+
+func startFakeInstance(t *testing.T) (*fakeInstance, *tarantool.Connection) {
+	t.Helper()
+
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	f := &fakeInstance{
+		Server:  server,
+		spaces:  make(map[string]*fakeSpace),
+		nextId:  512,
+		applied: make(map[uint64]string),
+	}
+	server.Handle(iproto.IPROTO_EVAL, f.eval)
+	server.Handle(iproto.IPROTO_SELECT, f.selectSchema)
+	return f, server.Connect(t, tarantool.Opts{})
+}
+
+func (f *fakeInstance) eval(req *mockserver.Request) ([]interface{}, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	expr := req.Expression()
+	args := req.Tuple()
+	switch {
+	case strings.Contains(expr, "loadstring"):
+		f.migrate++
+		for _, step := range args[0].([]interface{}) {
+			step := step.([]interface{})
+			if err := f.execute(step[0].(string), step[1].([]interface{})); err != nil {
+				return nil, err
+			}
+		}
+		version := toUint64(args[2])
+		if args[4].(bool) {
+			f.applied[version] = args[3].(string)
+		} else {
+			delete(f.applied, version)
+		}
+	case strings.Contains(expr, "space:pairs()"):
+		applied := []interface{}{}
+		for version, name := range f.applied {
+			applied = append(applied, map[string]interface{}{
+				"version":    version,
+				"name":       name,
+				"applied_at": 1700000000.5,
+			})
+		}
+		return []interface{}{applied}, nil
+	case strings.Contains(expr, "box.atomic"):
+		if f.lockOwner != "" && f.lockOwner != args[1] {
+			return []interface{}{f.lockOwner}, nil
+		}
+		f.lockOwner = args[1].(string)
+		return []interface{}{nil}, nil
+	case strings.Contains(expr, "lock:delete"):
+		if f.lockOwner == args[1] {
+			f.lockOwner = ""
+		}
+	}
+	return []interface{}{}, nil
+}
+
+// execute executes a declarative step. Other steps are recorded only.
+func (f *fakeInstance) execute(code string, args []interface{}) error {
+	f.steps = append(f.steps, code)
+	switch code {
+	case stepCode(migrations.CreateSpace{}):
+		name := args[0].(string)
+		if _, ok := f.spaces[name]; ok {
+			return fmt.Errorf("space %s already exists", name)
+		}
+		opts := args[1].(map[string]interface{})
+		format, _ := opts["format"].([]interface{})
+		f.spaces[name] = &fakeSpace{id: f.nextId, format: format}
+		f.nextId++
+	case stepCode(migrations.DropSpace{}):
+		delete(f.spaces, args[0].(string))
+	case stepCode(migrations.CreateIndex{}):
+		space := f.spaces[args[0].(string)]
+		opts := args[2].(map[string]interface{})
+		index := fakeIndex{name: args[1].(string), typ: "TREE", unique: opts["unique"].(bool)}
+		if typ, ok := opts["type"].(string); ok {
+			index.typ = typ
+		}
+		parts, ok := opts["parts"].([]interface{})
+		if !ok {
+			index.parts = []interface{}{map[string]interface{}{"field": 0, "type": "unsigned"}}
+		}
+		for _, part := range parts {
+			field := part.(map[string]interface{})["field"]
+			for i, formatField := range space.format {
+				if formatField.(map[string]interface{})["name"] == field {
+					index.parts = append(index.parts,
+						map[string]interface{}{"field": i, "type": "unsigned"})
+				}
+			}
+		}
+		space.indexes = append(space.indexes, index)
+	}
+	return nil
+}
+
+// selectSchema returns _vspace and _vindex contents.
+func (f *fakeInstance) selectSchema(req *mockserver.Request) ([]interface{}, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	tuples := []interface{}{}
+	for name, space := range f.spaces {
+		switch req.SpaceId() {
+		case 281: // _vspace
+			tuples = append(tuples, []interface{}{space.id, 1, name, "memtx", 0,
+				map[string]interface{}{}, space.format})
+		case 289: // _vindex
+			for i, index := range space.indexes {
+				tuples = append(tuples, []interface{}{space.id, i, index.name, index.typ,
+					map[string]interface{}{"unique": index.unique}, index.parts})
+			}
+		}
+	}
+	return tuples, nil
+}
+
+func (f *fakeInstance) appliedVersions() []uint64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var versions []uint64
+	for version := range f.applied {
+		versions = append(versions, version)
+	}
+	return versions
+}
+
+func toUint64(v interface{}) uint64 {
+	var n uint64
+	fmt.Sscan(fmt.Sprint(v), &n)
+	return n
+}
+
+func plannedVersions(plan migrations.Plan) []string {
+	var versions []string
+	for _, migration := range plan {
+		versions = append(versions, fmt.Sprintf("%s %d", migration.Direction,
+			migration.Version))
+	}
+	return versions
+}
+
+var testMigrations = []migrations.Migration{
+	{
+		Version: 2,
+		Name:    "create email index",
+		Up: []migrations.Step{
+			migrations.CreateIndex{
+				Space:  "users",
+				Name:   "email",
+				Type:   "HASH",
+				Unique: true,
+				Parts:  []box.IndexPart{{Field: "email"}},
+			},
+		},
+		Down: []migrations.Step{
+			migrations.DropIndex{Space: "users", Name: "email"},
+		},
+	},
+	{
+		Version: 1,
+		Name:    "create users",
+		Up: []migrations.Step{
+			migrations.CreateSpace{
+				Name: "users",
+				Format: []box.SpaceField{
+					{Name: "id", Type: "unsigned"},
+					{Name: "email", Type: "string"},
+				},
+			},
+			migrations.CreateIndex{
+				Space:  "users",
+				Name:   "primary",
+				Unique: true,
+				Parts:  []box.IndexPart{{Field: "id"}},
+			},
+			migrations.Lua{Code: "box.space.users:insert{1, 'admin@example.com'}"},
+		},
+		Down: []migrations.Step{
+			migrations.DropSpace{Name: "users"},
+		},
+	},
+}
+
+func TestNew_invalidVersions(t *testing.T) {
+	_, err := migrations.New(nil, []migrations.Migration{{Version: 0}}, migrations.Opts{})
+	assert.EqualError(t, err, "migration version must be positive")
+
+	_, err = migrations.New(nil, []migrations.Migration{{Version: 1}, {Version: 1}},
+		migrations.Opts{})
+	assert.EqualError(t, err, "duplicate migration version 1")
+}
+
+func TestMigrator_up(t *testing.T) {
+	instance, conn := startFakeInstance(t)
+	ctx := context.Background()
+
+	migrator, err := migrations.New(conn, testMigrations, migrations.Opts{})
+	require.NoError(t, err)
+
+	plan, err := migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up 1", "up 2"}, plannedVersions(plan))
+	assert.ElementsMatch(t, []uint64{1, 2}, instance.appliedVersions())
+	assert.Equal(t, "", instance.lockOwner)
+
+	applied, err := migrator.Applied(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, uint64(1), applied[0].Version)
+	assert.Equal(t, "create users", applied[0].Name)
+	assert.Equal(t, time.Unix(1700000000, 5e8), applied[0].AppliedAt)
+
+	// Nothing to apply.
+	plan, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+	assert.Equal(t, 2, instance.migrate)
+}
+
+func TestMigrator_upEmpty(t *testing.T) {
+	instance, conn := startFakeInstance(t)
+
+	migrator, err := migrations.New(conn, nil, migrations.Opts{})
+	require.NoError(t, err)
+
+	plan, err := migrator.Up(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+	assert.Equal(t, 0, instance.migrate)
+}
+
+func TestMigrator_defaultParts(t *testing.T) {
+	_, conn := startFakeInstance(t)
+
+	migrator, err := migrations.New(conn, []migrations.Migration{
+		{
+			Version: 1,
+			Name:    "create events",
+			Up: []migrations.Step{
+				migrations.CreateSpace{Name: "events"},
+				migrations.CreateIndex{Space: "events", Name: "primary", Unique: true},
+			},
+		},
+	}, migrations.Opts{})
+	require.NoError(t, err)
+
+	plan, err := migrator.Up(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up 1"}, plannedVersions(plan))
+}
+
+func TestMigrator_upAndDown(t *testing.T) {
+	instance, conn := startFakeInstance(t)
+	ctx := context.Background()
+
+	migrator, err := migrations.New(conn, testMigrations, migrations.Opts{})
+	require.NoError(t, err)
+
+	plan, err := migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up 1"}, plannedVersions(plan))
+
+	plan, err = migrator.Up(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up 2"}, plannedVersions(plan))
+
+	plan, err = migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"down 2", "down 1"}, plannedVersions(plan))
+	assert.Empty(t, instance.appliedVersions())
+	assert.Empty(t, instance.spaces)
+
+	_, err = migrator.Up(ctx, 3)
+	assert.EqualError(t, err, "unknown migration version 3")
+}
+
+func TestMigrator_dryRun(t *testing.T) {
+	instance, conn := startFakeInstance(t)
+	ctx := context.Background()
+
+	migrator, err := migrations.New(conn, testMigrations, migrations.Opts{DryRun: true})
+	require.NoError(t, err)
+
+	plan, err := migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up 1"}, plannedVersions(plan))
+	assert.Equal(t, 0, instance.migrate)
+
+	output := plan.String()
+	assert.Contains(t, output, `-- up 1 "create users"`)
+	assert.Contains(t, output, `-- step 1: ... = "users", `+
+		`{format = {{name = "id", type = "unsigned"}, {name = "email", type = "string"}}}`)
+	assert.Contains(t, output, "box.schema.space.create(name, opts)\n")
+	assert.Contains(t, output, "-- step 3: ... = \n"+
+		"box.space.users:insert{1, 'admin@example.com'}\n")
+}
+
+func TestPlan_String_quote(t *testing.T) {
+	plan := migrations.Plan{{
+		Migration: migrations.Migration{
+			Version: 1,
+			Name:    "quote",
+			Up: []migrations.Step{
+				migrations.Lua{
+					Code: "return ...",
+					Args: []interface{}{
+						"caf\u00e9 \"q\" \\ \n\t\x01",
+						map[string]interface{}{"key\x00": "1\x7f2"},
+					},
+				},
+			},
+		},
+	}}
+
+	// Lua 5.1 has no \u and \x escapes, other bytes are escaped in decimal.
+	assert.Contains(t, plan.String(),
+		`-- step 1: ... = "caf\195\169 \"q\" \\ \n\t\001", {["key\000"] = "1\1272"}`)
+}
+
+func TestMigrator_locked(t *testing.T) {
+	instance, conn := startFakeInstance(t)
+	instance.lockOwner = "other"
+
+	migrator, err := migrations.New(conn, testMigrations, migrations.Opts{Owner: "me"})
+	require.NoError(t, err)
+
+	_, err = migrator.Up(context.Background(), 0)
+	assert.True(t, errors.Is(err, migrations.ErrLocked))
+	assert.EqualError(t, err, "migrations are locked by other")
+	assert.Equal(t, 0, instance.migrate)
+}
+
+func TestMigrator_verify(t *testing.T) {
+	_, conn := startFakeInstance(t)
+	ctx := context.Background()
+
+	notUnique := false
+	alter := append([]migrations.Migration{}, testMigrations...)
+	alter = append(alter, migrations.Migration{
+		Version: 3,
+		Name:    "alter email index",
+		Up: []migrations.Step{
+			migrations.AlterIndex{Space: "users", Name: "email", Unique: &notUnique},
+		},
+	})
+	migrator, err := migrations.New(conn, alter, migrations.Opts{})
+	require.NoError(t, err)
+
+	// The fake instance does not alter indexes.
+	plan, err := migrator.Up(ctx, 0)
+	assert.EqualError(t, err, `schema verification failed: `+
+		`index "email" of space "users" has unique true, expected false`)
+	assert.Len(t, plan, 3)
+
+	_, err = migrator.Down(ctx, 2)
+	assert.EqualError(t, err, "migration 3 could not be rolled back")
+}
+
+func TestMigrator_unknownApplied(t *testing.T) {
+	instance, conn := startFakeInstance(t)
+	instance.applied[5] = "unknown"
+
+	migrator, err := migrations.New(conn, testMigrations, migrations.Opts{})
+	require.NoError(t, err)
+
+	_, err = migrator.Up(context.Background(), 0)
+	assert.EqualError(t, err, `applied migration 5 "unknown" is unknown`)
+}
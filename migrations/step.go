@@ -0,0 +1,192 @@
+package migrations
+
+import (
+	"github.com/tarantool/go-tarantool/v2/box"
+)
+
+// Step is a step of a migration. A step is executed on a Tarantool instance
+// as a Lua chunk.
+type Step interface {
+	// Lua returns a Lua chunk of the step and its arguments. The arguments
+	// are available in the chunk as "...".
+	Lua() (code string, args []interface{})
+}
+
+// CreateSpace creates a space with box.schema.space.create().
+type CreateSpace struct {
+	Name string
+	// Engine is "memtx" or "vinyl". It is "memtx" by default.
+	Engine      string
+	Temporary   bool
+	IfNotExists bool
+	Format      []box.SpaceField
+}
+
+// Lua returns a Lua chunk of the step.
+func (s CreateSpace) Lua() (string, []interface{}) {
+	opts := map[string]interface{}{}
+	if s.Engine != "" {
+		opts["engine"] = s.Engine
+	}
+	if s.Temporary {
+		opts["temporary"] = true
+	}
+	if s.IfNotExists {
+		opts["if_not_exists"] = true
+	}
+	if s.Format != nil {
+		opts["format"] = s.Format
+	}
+	return createSpaceLua, []interface{}{s.Name, opts}
+}
+
+func (s CreateSpace) apply(state *schemaState) {
+	state.createSpace(s.Name, s.Format)
+}
+
+// SetFormat sets a format of a space with space:format().
+type SetFormat struct {
+	Space  string
+	Format []box.SpaceField
+}
+
+// Lua returns a Lua chunk of the step.
+func (s SetFormat) Lua() (string, []interface{}) {
+	return setFormatLua, []interface{}{s.Space, s.Format}
+}
+
+func (s SetFormat) apply(state *schemaState) {
+	if space := state.space(s.Space); space != nil {
+		space.format = s.Format
+	}
+}
+
+// DropSpace drops a space with space:drop().
+type DropSpace struct {
+	Name     string
+	IfExists bool
+}
+
+// Lua returns a Lua chunk of the step.
+func (s DropSpace) Lua() (string, []interface{}) {
+	return dropSpaceLua, []interface{}{s.Name, s.IfExists}
+}
+
+func (s DropSpace) apply(state *schemaState) {
+	state.dropSpace(s.Name)
+}
+
+// CreateIndex creates an index with space:create_index().
+type CreateIndex struct {
+	Space string
+	Name  string
+	// Type is "TREE", "HASH", "BITSET" or "RTREE". It is "TREE" by default.
+	Type string
+	// Unique must be set for a primary index.
+	Unique bool
+	// Parts are parts of the index. The index is built on the first
+	// unsigned field by default.
+	Parts       []box.IndexPart
+	IfNotExists bool
+}
+
+// Lua returns a Lua chunk of the step.
+func (s CreateIndex) Lua() (string, []interface{}) {
+	opts := map[string]interface{}{
+		"unique": s.Unique,
+	}
+	if s.Parts != nil {
+		opts["parts"] = s.Parts
+	}
+	if s.Type != "" {
+		opts["type"] = s.Type
+	}
+	if s.IfNotExists {
+		opts["if_not_exists"] = true
+	}
+	return createIndexLua, []interface{}{s.Space, s.Name, opts}
+}
+
+func (s CreateIndex) apply(state *schemaState) {
+	if space := state.space(s.Space); space != nil {
+		unique := s.Unique
+		space.indexes[s.Name] = &indexState{
+			typ:    s.Type,
+			unique: &unique,
+			parts:  s.Parts,
+		}
+	}
+}
+
+// AlterIndex changes an index with index:alter(). Only specified options
+// are changed.
+type AlterIndex struct {
+	Space  string
+	Name   string
+	Type   string
+	Unique *bool
+	Parts  []box.IndexPart
+}
+
+// Lua returns a Lua chunk of the step.
+func (s AlterIndex) Lua() (string, []interface{}) {
+	opts := map[string]interface{}{}
+	if s.Type != "" {
+		opts["type"] = s.Type
+	}
+	if s.Unique != nil {
+		opts["unique"] = *s.Unique
+	}
+	if s.Parts != nil {
+		opts["parts"] = s.Parts
+	}
+	return alterIndexLua, []interface{}{s.Space, s.Name, opts}
+}
+
+func (s AlterIndex) apply(state *schemaState) {
+	space := state.space(s.Space)
+	if space == nil {
+		return
+	}
+	if index := space.indexes[s.Name]; index != nil {
+		if s.Type != "" {
+			index.typ = s.Type
+		}
+		if s.Unique != nil {
+			index.unique = s.Unique
+		}
+		if s.Parts != nil {
+			index.parts = s.Parts
+		}
+	}
+}
+
+// DropIndex drops an index with index:drop().
+type DropIndex struct {
+	Space    string
+	Name     string
+	IfExists bool
+}
+
+// Lua returns a Lua chunk of the step.
+func (s DropIndex) Lua() (string, []interface{}) {
+	return dropIndexLua, []interface{}{s.Space, s.Name, s.IfExists}
+}
+
+func (s DropIndex) apply(state *schemaState) {
+	if space := state.space(s.Space); space != nil {
+		space.indexes[s.Name] = nil
+	}
+}
+
+// Lua is a Lua chunk with arguments. Changes made by the chunk are not
+// verified after a migration.
+type Lua struct {
+	Code string
+	Args []interface{}
+}
+
+// Lua returns the Lua chunk.
+func (s Lua) Lua() (string, []interface{}) {
+	return s.Code, s.Args
+}
@@ -0,0 +1,196 @@
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/box"
+)
+
+// schemaStep is implemented by declarative steps that change an expected
+// state of a schema.
+type schemaStep interface {
+	apply(state *schemaState)
+}
+
+// schemaState is an expected state of spaces after migrations. A nil space
+// or index is expected to be dropped.
+type schemaState struct {
+	spaces map[string]*spaceState
+}
+
+type spaceState struct {
+	// format is nil if a format is unknown.
+	format  []box.SpaceField
+	indexes map[string]*indexState
+}
+
+type indexState struct {
+	typ    string
+	unique *bool
+	// parts are nil if parts are unknown.
+	parts []box.IndexPart
+}
+
+// apply changes the state with declarative steps.
+func (s *schemaState) apply(steps []Step) {
+	for _, step := range steps {
+		if step, ok := step.(schemaStep); ok {
+			step.apply(s)
+		}
+	}
+}
+
+func (s *schemaState) createSpace(name string, format []box.SpaceField) {
+	if space := s.spaces[name]; space != nil {
+		if format != nil {
+			space.format = format
+		}
+		return
+	}
+	s.spaces[name] = &spaceState{
+		format:  format,
+		indexes: make(map[string]*indexState),
+	}
+}
+
+// space returns a state of an existing space. The space could be created
+// by a Lua step, so its format is unknown.
+func (s *schemaState) space(name string) *spaceState {
+	if s.spaces[name] == nil {
+		s.createSpace(name, nil)
+	}
+	return s.spaces[name]
+}
+
+func (s *schemaState) dropSpace(name string) {
+	s.spaces[name] = nil
+}
+
+// verify compares the expected state with the schema.
+func (s *schemaState) verify(schema tarantool.Schema) error {
+	var problems []string
+	for _, name := range sortedKeys(s.spaces) {
+		expected := s.spaces[name]
+		actual, ok := schema.Spaces[name]
+		switch {
+		case expected == nil && ok:
+			problems = append(problems, fmt.Sprintf("space %q exists", name))
+		case expected != nil && !ok:
+			problems = append(problems, fmt.Sprintf("space %q does not exist", name))
+		case expected != nil:
+			problems = append(problems, expected.verify(name, actual)...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("schema verification failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (s *spaceState) verify(name string, actual tarantool.Space) []string {
+	var problems []string
+	if s.format != nil {
+		if len(s.format) != len(actual.FieldsById) {
+			problems = append(problems, fmt.Sprintf("space %q has %d fields, expected %d",
+				name, len(actual.FieldsById), len(s.format)))
+		} else {
+			for i, field := range s.format {
+				actualField := actual.FieldsById[uint32(i)]
+				if field.Name != actualField.Name ||
+					!strings.EqualFold(fieldType(field.Type), actualField.Type) ||
+					field.IsNullable != actualField.IsNullable {
+					problems = append(problems, fmt.Sprintf(
+						"field %d of space %q is %+v, expected %+v",
+						i+1, name, actualField, field))
+				}
+			}
+		}
+	}
+
+	for _, indexName := range sortedKeys(s.indexes) {
+		expected := s.indexes[indexName]
+		actualIndex, ok := actual.Indexes[indexName]
+		switch {
+		case expected == nil && ok:
+			problems = append(problems,
+				fmt.Sprintf("index %q of space %q exists", indexName, name))
+		case expected != nil && !ok:
+			problems = append(problems,
+				fmt.Sprintf("index %q of space %q does not exist", indexName, name))
+		case expected != nil:
+			if problem := expected.verify(actual, actualIndex); problem != "" {
+				problems = append(problems, fmt.Sprintf("index %q of space %q %s",
+					indexName, name, problem))
+			}
+		}
+	}
+	return problems
+}
+
+func (s *indexState) verify(space tarantool.Space, actual tarantool.Index) string {
+	if s.typ != "" && !strings.EqualFold(s.typ, actual.Type) {
+		return fmt.Sprintf("has type %s, expected %s", actual.Type, s.typ)
+	}
+	if s.unique != nil && *s.unique != actual.Unique {
+		return fmt.Sprintf("has unique %t, expected %t", actual.Unique, *s.unique)
+	}
+	if s.parts == nil {
+		// Tarantool builds an index on the first field by default.
+		return ""
+	}
+	if len(s.parts) != len(actual.Fields) {
+		return fmt.Sprintf("has %d parts, expected %d", len(actual.Fields), len(s.parts))
+	}
+	for i, part := range s.parts {
+		id, ok := partFieldId(space, part.Field)
+		if !ok || id != actual.Fields[i].Id ||
+			(part.Type != "" && !strings.EqualFold(part.Type, actual.Fields[i].Type)) {
+			return fmt.Sprintf("has part %d on field %d of type %s, expected %v of type %s",
+				i+1, actual.Fields[i].Id+1, actual.Fields[i].Type, part.Field, part.Type)
+		}
+	}
+	return ""
+}
+
+// fieldType returns a type of a field in a schema, a field without a type
+// has the "any" type.
+func fieldType(typ string) string {
+	if typ == "" {
+		return "any"
+	}
+	return typ
+}
+
+// partFieldId returns an id of a field of an index part. The field is a
+// name or a number starting from 1, see box.IndexPart.
+func partFieldId(space tarantool.Space, field interface{}) (uint32, bool) {
+	switch field := field.(type) {
+	case string:
+		f, ok := space.Fields[field]
+		return f.Id, ok
+	case int:
+		return uint32(field - 1), field > 0
+	case uint:
+		return uint32(field - 1), field > 0
+	case int64:
+		return uint32(field - 1), field > 0
+	case uint64:
+		return uint32(field - 1), field > 0
+	case uint32:
+		return field - 1, field > 0
+	}
+	return 0, false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
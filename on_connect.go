@@ -0,0 +1,70 @@
+package tarantool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// OnConnectFunc is called on every successful connection and reconnection
+// to restore a state of a new session: settings, a session user, a queue
+// session UUID and so on. It could be set with Opts.OnConnect.
+//
+// The function is called before the Connected event is delivered and before
+// any user request is sent. The doer performs requests synchronously over
+// the new network connection: a returned Future is always done. Requests
+// are not passed to Opts.Interceptors, Opts.Tracer and Opts.Metrics.
+//
+// If the function returns an error, the network connection is closed and
+// the error is processed as a connection error: Connect() fails or the
+// connection reconnects according to Opts.Reconnect.
+type OnConnectFunc func(ctx context.Context, doer Doer) error
+
+// OnConnectRequests returns an OnConnectFunc that performs the requests in
+// order. It stops on the first failed request and returns its error.
+func OnConnectRequests(reqs ...Request) OnConnectFunc {
+	return func(ctx context.Context, doer Doer) error {
+		for _, req := range reqs {
+			if _, err := doer.Do(req).Get(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// onConnectDoer performs requests synchronously over a network connection
+// that is not yet used by a Connection.
+type onConnectDoer struct {
+	c        Conn
+	resolver SchemaResolver
+}
+
+// Do sends the request and waits for a response.
+func (d onConnectDoer) Do(req Request) *Future {
+	fut := NewFuture(req)
+
+	var packet smallWBuf
+	_, err := pack(&packet, msgpack.NewEncoder(&packet), 0, req, ignoreStreamId,
+		d.resolver, &versionedResolver{})
+	if err != nil {
+		fut.SetError(fmt.Errorf("pack error: %w", err))
+		return fut
+	}
+	if _, err = d.c.Write(packet.b); err != nil {
+		fut.SetError(fmt.Errorf("write error: %w", err))
+		return fut
+	}
+	if err = d.c.Flush(); err != nil {
+		fut.SetError(fmt.Errorf("flush error: %w", err))
+		return fut
+	}
+
+	if req.Async() {
+		fut.SetResponse(Header{Error: ErrorNo}, nil)
+		return fut
+	}
+	fut.setResult(readResponse(d.c, req))
+	return fut
+}
@@ -0,0 +1,162 @@
+package tarantool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func calledFunctions(server *mockserver.Server) []string {
+	var functions []string
+	for _, req := range server.Requests() {
+		if req.Type == iproto.IPROTO_CALL {
+			functions = append(functions, req.FunctionName())
+		}
+	}
+	return functions
+}
+
+func TestOnConnect(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("init", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{true}, nil
+	})
+	server.HandleCall("work", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{true}, nil
+	})
+
+	var mutex sync.Mutex
+	var kinds []ConnEventKind
+	events := make(chan ConnEvent, 100)
+	drain := func() {
+		for {
+			select {
+			case event := <-events:
+				kinds = append(kinds, event.Kind)
+			default:
+				return
+			}
+		}
+	}
+
+	var calls int32
+	init := OnConnectRequests(NewCallRequest("init"))
+	conn := server.Connect(t, Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		Notify:        events,
+		OnConnect: func(ctx context.Context, doer Doer) error {
+			mutex.Lock()
+			drain()
+			// Zero marks the callback.
+			kinds = append(kinds, 0)
+			mutex.Unlock()
+
+			atomic.AddInt32(&calls, 1)
+			return init(ctx, doer)
+		},
+	})
+
+	_, err := conn.Do(NewCallRequest("work")).Get()
+	require.NoError(t, err)
+
+	server.DropConnections()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 2 && conn.ConnectedNow()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = conn.Do(NewCallRequest("work")).Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"init", "work", "init", "work"}, calledFunctions(server))
+	mutex.Lock()
+	drain()
+	assert.Equal(t, []ConnEventKind{0, Connected, Disconnected, 0, Connected}, kinds)
+	mutex.Unlock()
+}
+
+func TestOnConnect_error(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	dialer := NetDialer{Address: server.Addr()}
+
+	errInit := errors.New("init error")
+	_, err := Connect(ctx, dialer, Opts{
+		SkipSchema: true,
+		OnConnect: func(ctx context.Context, doer Doer) error {
+			return errInit
+		},
+	})
+	require.ErrorIs(t, err, errInit)
+
+	// A failed request rejects the connection too.
+	_, err = Connect(ctx, dialer, Opts{
+		SkipSchema: true,
+		OnConnect:  OnConnectRequests(NewCallRequest("unknown")),
+	})
+	var tntErr Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_NO_SUCH_PROC, tntErr.Code)
+}
+
+func TestOnConnect_reconnect(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	// The callback fails once after the first connection.
+	var calls int32
+	events := make(chan ConnEvent, 100)
+	conn := server.Connect(t, Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		Notify:        events,
+		OnConnect: func(ctx context.Context, doer Doer) error {
+			if atomic.AddInt32(&calls, 1) == 2 {
+				return errors.New("init error")
+			}
+			_, err := doer.Do(NewPingRequest()).Get()
+			return err
+		},
+	})
+	require.Equal(t, Connected, (<-events).Kind)
+
+	server.DropConnections()
+	require.Equal(t, Disconnected, (<-events).Kind)
+	require.Equal(t, ReconnectFailed, (<-events).Kind)
+	require.Equal(t, Connected, (<-events).Kind)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	_, err := conn.Do(NewPingRequest()).Get()
+	require.NoError(t, err)
+}
+
+func TestOnConnect_push(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("init", func(req *mockserver.Request) ([]interface{}, error) {
+		if err := req.Push("progress"); err != nil {
+			return nil, err
+		}
+		return []interface{}{"done"}, nil
+	})
+
+	var data []interface{}
+	server.Connect(t, Opts{
+		OnConnect: func(ctx context.Context, doer Doer) error {
+			var err error
+			data, err = doer.Do(NewCallRequest("init")).Get()
+			return err
+		},
+	})
+	assert.Equal(t, []interface{}{"done"}, data)
+}
@@ -0,0 +1,31 @@
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// InjectArgs returns arguments of a stored procedure call with a trace
+// context from ctx appended as the last argument. The trace context is a map
+// of fields of the global propagator, for example, "traceparent" and
+// "tracestate" for the W3C Trace Context propagator.
+//
+//	args := otel.InjectArgs(ctx, []interface{}{1, "foo"})
+//	req := tarantool.NewCallRequest("traced_func").Context(ctx).Args(args)
+//
+// The function on the Tarantool side receives the map as a Lua table:
+//
+//	function traced_func(a, b, trace_context) ... end
+//
+// A span created by Tracer is not available before sending the request, so
+// the passed trace context refers to the span from ctx.
+func InjectArgs(ctx context.Context, args []interface{}) []interface{} {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	injected := make([]interface{}, 0, len(args)+1)
+	injected = append(injected, args...)
+	return append(injected, map[string]string(carrier))
+}
@@ -0,0 +1,103 @@
+package otel
+
+import (
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// target describes a space, an index, a function or a statement of a
+// request.
+type target struct {
+	// name is a short description of the target for a span name.
+	name       string
+	attributes []attribute.KeyValue
+}
+
+// spaceRequest is a request to a space, see tarantool.InsertRequest.
+type spaceRequest interface {
+	GetSpace() interface{}
+}
+
+// indexRequest is a request to an index, see tarantool.SelectRequest.
+type indexRequest interface {
+	GetIndex() interface{}
+}
+
+// functionRequest is a request that calls a function, see
+// tarantool.CallRequest.
+type functionRequest interface {
+	GetFunction() string
+}
+
+// statementRequest is a request that executes an SQL statement, see
+// tarantool.ExecuteRequest.
+type statementRequest interface {
+	GetExpr() string
+}
+
+// requestTarget returns a target of the request.
+func requestTarget(req tarantool.Request) target {
+	var t target
+	if r, ok := req.(spaceRequest); ok {
+		switch space := r.GetSpace().(type) {
+		case string:
+			t.name = space
+			t.attributes = append(t.attributes, SpaceKey.String(space))
+		default:
+			if id, ok := toId(space); ok {
+				t.name = strconv.FormatUint(uint64(id), 10)
+				t.attributes = append(t.attributes, SpaceIdKey.Int64(int64(id)))
+			}
+		}
+	}
+	if r, ok := req.(indexRequest); ok {
+		switch index := r.GetIndex().(type) {
+		case nil:
+			// The primary index is used by default.
+			t.attributes = append(t.attributes, IndexIdKey.Int64(0))
+		case string:
+			t.attributes = append(t.attributes, IndexKey.String(index))
+		default:
+			if id, ok := toId(index); ok {
+				t.attributes = append(t.attributes, IndexIdKey.Int64(int64(id)))
+			}
+		}
+	}
+	if r, ok := req.(functionRequest); ok {
+		t.name = r.GetFunction()
+		t.attributes = append(t.attributes, FunctionKey.String(t.name))
+	}
+	if r, ok := req.(statementRequest); ok {
+		t.attributes = append(t.attributes, StatementKey.String(r.GetExpr()))
+	}
+	return t
+}
+
+func toId(v interface{}) (uint32, bool) {
+	switch id := v.(type) {
+	case uint:
+		return uint32(id), true
+	case uint8:
+		return uint32(id), true
+	case uint16:
+		return uint32(id), true
+	case uint32:
+		return id, true
+	case uint64:
+		return uint32(id), true
+	case int:
+		return uint32(id), true
+	case int8:
+		return uint32(id), true
+	case int16:
+		return uint32(id), true
+	case int32:
+		return uint32(id), true
+	case int64:
+		return uint32(id), true
+	}
+	return 0, false
+}
@@ -0,0 +1,194 @@
+// Package otel provides OpenTelemetry tracing of requests of connections
+// and connection pools.
+//
+// The package is a separate Go module, so the connector does not depend on
+// the OpenTelemetry libraries.
+//
+// Usage:
+//
+//	opts := tarantool.Opts{Tracer: otel.NewTracer(otel.Opts{})}
+//	conn, err := tarantool.Connect(ctx, dialer, opts)
+//
+//	poolOpts := pool.Opts{
+//		CheckTimeout: time.Second,
+//		Tracer:       otel.NewPoolTracer(otel.Opts{}),
+//	}
+//	connPool, err := pool.ConnectWithOpts(ctx, instances, poolOpts)
+//
+// A span of a request is a child of a span from the request context, see
+// Context() methods of requests.
+//
+// IPROTO does not allow to pass custom fields in a request header, so a
+// trace context could be passed to Tarantool only with arguments of a stored
+// procedure, see InjectArgs.
+package otel
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+)
+
+// instrumentationName is a name of the instrumentation library.
+const instrumentationName = "github.com/tarantool/go-tarantool/v2/otel"
+
+// Attribute keys of spans.
+const (
+	// SystemKey is a key of the database system attribute. The value is
+	// always "tarantool".
+	SystemKey = attribute.Key("db.system")
+	// OperationKey is a key of a request type attribute, for example,
+	// "SELECT" or "CALL".
+	OperationKey = attribute.Key("db.operation")
+	// ServerAddressKey is a key of a server address attribute.
+	ServerAddressKey = attribute.Key("server.address")
+	// ServerPortKey is a key of a server port attribute.
+	ServerPortKey = attribute.Key("server.port")
+	// RequestIdKey is a key of a request ID (IPROTO_SYNC) attribute.
+	RequestIdKey = attribute.Key("db.tarantool.request_id")
+	// SpaceKey is a key of a space name attribute.
+	SpaceKey = attribute.Key("db.tarantool.space")
+	// SpaceIdKey is a key of a space ID attribute.
+	SpaceIdKey = attribute.Key("db.tarantool.space_id")
+	// IndexKey is a key of an index name attribute.
+	IndexKey = attribute.Key("db.tarantool.index")
+	// IndexIdKey is a key of an index ID attribute.
+	IndexIdKey = attribute.Key("db.tarantool.index_id")
+	// FunctionKey is a key of a called function name attribute.
+	FunctionKey = attribute.Key("db.tarantool.function")
+	// StatementKey is a key of an executed SQL statement attribute.
+	StatementKey = attribute.Key("db.statement")
+	// ErrorCodeKey is a key of an error code attribute of a failed request.
+	ErrorCodeKey = attribute.Key("db.tarantool.error_code")
+	// InstanceKey is a key of a pool instance name attribute.
+	InstanceKey = attribute.Key("db.tarantool.instance")
+	// RoleKey is a key of a pool instance role attribute.
+	RoleKey = attribute.Key("db.tarantool.role")
+)
+
+// Opts configures a Tracer or a PoolTracer.
+type Opts struct {
+	// TracerProvider is used to create a tracer. By default, the global
+	// provider is used.
+	TracerProvider trace.TracerProvider
+	// Attributes are attributes added to all spans.
+	Attributes []attribute.KeyValue
+}
+
+// Tracer creates spans for requests of a connection. It implements the
+// tarantool.Tracer interface.
+type Tracer struct {
+	tracer     trace.Tracer
+	attributes []attribute.KeyValue
+}
+
+var _ tarantool.Tracer = (*Tracer)(nil)
+
+// NewTracer creates a new Tracer.
+func NewTracer(opts Opts) *Tracer {
+	provider := opts.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &Tracer{
+		tracer:     provider.Tracer(instrumentationName),
+		attributes: opts.Attributes,
+	}
+}
+
+// StartTrace makes Tracer satisfy the tarantool.Tracer interface.
+func (t *Tracer) StartTrace(info tarantool.TraceInfo) tarantool.TraceFinish {
+	return t.start(info)
+}
+
+func (t *Tracer) start(info tarantool.TraceInfo,
+	attrs ...attribute.KeyValue) tarantool.TraceFinish {
+	ctx := info.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	target := requestTarget(info.Request)
+	operation := strings.TrimPrefix(info.Request.Type().String(), "IPROTO_")
+	name := operation
+	if target.name != "" {
+		name += " " + target.name
+	}
+
+	attrs = append(attrs,
+		SystemKey.String("tarantool"),
+		OperationKey.String(operation),
+		RequestIdKey.Int64(int64(info.RequestId)),
+	)
+	attrs = append(attrs, target.attributes...)
+	attrs = append(attrs, connAttributes(info.Conn)...)
+	attrs = append(attrs, t.attributes...)
+
+	_, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return func(resp tarantool.Response, err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if resp != nil {
+			if code := resp.Header().Error; code != tarantool.ErrorNo {
+				span.SetAttributes(ErrorCodeKey.String(code.String()))
+				span.SetStatus(codes.Error, code.String())
+			}
+		}
+		span.End()
+	}
+}
+
+// PoolTracer creates spans for requests of a connection pool. It implements
+// the pool.Tracer interface.
+type PoolTracer struct {
+	tracer *Tracer
+}
+
+var _ pool.Tracer = (*PoolTracer)(nil)
+
+// NewPoolTracer creates a new PoolTracer.
+func NewPoolTracer(opts Opts) *PoolTracer {
+	return &PoolTracer{tracer: NewTracer(opts)}
+}
+
+// StartTrace makes PoolTracer satisfy the pool.Tracer interface.
+func (t *PoolTracer) StartTrace(info pool.TraceInfo) tarantool.TraceFinish {
+	attrs := []attribute.KeyValue{RoleKey.String(info.Role.String())}
+	if info.Instance != "" {
+		attrs = append(attrs, InstanceKey.String(info.Instance))
+	}
+	return t.tracer.start(info.TraceInfo, attrs...)
+}
+
+func connAttributes(conn *tarantool.Connection) []attribute.KeyValue {
+	if conn == nil {
+		return nil
+	}
+	addr := conn.Addr()
+	if addr == nil {
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return []attribute.KeyValue{ServerAddressKey.String(addr.String())}
+	}
+	attrs := []attribute.KeyValue{ServerAddressKey.String(host)}
+	if portNum, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, ServerPortKey.Int(portNum))
+	}
+	return attrs
+}
@@ -0,0 +1,213 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tarantool/go-tarantool/v2"
+	tntotel "github.com/tarantool/go-tarantool/v2/otel"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const timeout = 5 * time.Second
+
+func newProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+// findSpans returns ended spans with the operation.
+func findSpans(recorder *tracetest.SpanRecorder,
+	operation string) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if attr(span, tntotel.OperationKey).AsString() == operation {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracer(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("fail", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, tarantool.Error{Code: iproto.ER_PROC_LUA, Msg: "fail"}
+	})
+	server.Handle(iproto.IPROTO_SELECT,
+		func(req *mockserver.Request) ([]interface{}, error) {
+			return []interface{}{}, nil
+		})
+
+	server.Handle(iproto.IPROTO_EXECUTE,
+		func(req *mockserver.Request) ([]interface{}, error) {
+			return []interface{}{}, nil
+		})
+
+	provider, recorder := newProvider()
+	conn := server.Connect(t, tarantool.Opts{
+		Tracer: tntotel.NewTracer(tntotel.Opts{
+			TracerProvider: provider,
+			Attributes:     []attribute.KeyValue{attribute.String("foo", "bar")},
+		}),
+	})
+
+	parentCtx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err := conn.Do(tarantool.NewSelectRequest(512).Index(1).
+		Context(parentCtx)).Get()
+	require.NoError(t, err)
+	parent.End()
+
+	_, err = conn.Do(tarantool.NewCallRequest("fail")).Get()
+	require.Error(t, err)
+	_, err = conn.Do(tarantool.NewExecuteRequest("SELECT 1")).Get()
+	require.NoError(t, err)
+
+	selects := findSpans(recorder, "SELECT")
+	require.Len(t, selects, 1)
+	span := selects[0]
+	assert.Equal(t, "SELECT 512", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, "tarantool", attr(span, tntotel.SystemKey).AsString())
+	assert.Equal(t, int64(512), attr(span, tntotel.SpaceIdKey).AsInt64())
+	assert.Equal(t, int64(1), attr(span, tntotel.IndexIdKey).AsInt64())
+	assert.Equal(t, "127.0.0.1", attr(span, tntotel.ServerAddressKey).AsString())
+	assert.NotZero(t, attr(span, tntotel.ServerPortKey).AsInt64())
+	assert.NotZero(t, attr(span, tntotel.RequestIdKey).AsInt64())
+	assert.Equal(t, "bar", attr(span, "foo").AsString())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+
+	calls := findSpans(recorder, "CALL")
+	require.Len(t, calls, 1)
+	span = calls[0]
+	assert.Equal(t, "CALL fail", span.Name())
+	assert.Equal(t, "fail", attr(span, tntotel.FunctionKey).AsString())
+	assert.Equal(t, "ER_PROC_LUA", attr(span, tntotel.ErrorCodeKey).AsString())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	executes := findSpans(recorder, "EXECUTE")
+	require.Len(t, executes, 1)
+	span = executes[0]
+	assert.Equal(t, "EXECUTE", span.Name())
+	assert.Equal(t, "SELECT 1", attr(span, tntotel.StatementKey).AsString())
+}
+
+func TestTracer_clientError(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	provider, recorder := newProvider()
+	conn := server.Connect(t, tarantool.Opts{
+		Tracer: tntotel.NewTracer(tntotel.Opts{TracerProvider: provider}),
+	})
+	conn.Close()
+
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	require.Error(t, err)
+
+	pings := findSpans(recorder, "PING")
+	require.Len(t, pings, 1)
+	assert.Equal(t, codes.Error, pings[0].Status().Code)
+	require.Len(t, pings[0].Events(), 1)
+	assert.Equal(t, "exception", pings[0].Events()[0].Name)
+}
+
+func TestPoolTracer(t *testing.T) {
+	rw := mockserver.StartTest(t, mockserver.Opts{})
+	ro := mockserver.StartTest(t, mockserver.Opts{ReadOnly: true})
+
+	provider, recorder := newProvider()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, []pool.Instance{
+		{
+			Name:   "rw",
+			Dialer: tarantool.NetDialer{Address: rw.Addr()},
+			Opts:   tarantool.Opts{Timeout: timeout},
+		},
+		{
+			Name:   "ro",
+			Dialer: tarantool.NetDialer{Address: ro.Addr()},
+			Opts:   tarantool.Opts{Timeout: timeout},
+		},
+	}, pool.Opts{
+		CheckTimeout: timeout,
+		Tracer:       tntotel.NewPoolTracer(tntotel.Opts{TracerProvider: provider}),
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	_, err = connPool.Do(tarantool.NewPingRequest(), pool.RW).Get()
+	require.NoError(t, err)
+	_, err = connPool.Do(tarantool.NewPingRequest(), pool.RO).Get()
+	require.NoError(t, err)
+	_, err = connPool.DoInstance(tarantool.NewPingRequest(), "unknown").Get()
+	require.Error(t, err)
+
+	pings := findSpans(recorder, "PING")
+	require.Len(t, pings, 3)
+
+	assert.Equal(t, "rw", attr(pings[0], tntotel.InstanceKey).AsString())
+	assert.Equal(t, "master", attr(pings[0], tntotel.RoleKey).AsString())
+	assert.Equal(t, "ro", attr(pings[1], tntotel.InstanceKey).AsString())
+	assert.Equal(t, "replica", attr(pings[1], tntotel.RoleKey).AsString())
+	assert.Equal(t, "unknown", attr(pings[2], tntotel.InstanceKey).AsString())
+	assert.Equal(t, codes.Error, pings[2].Status().Code)
+}
+
+func TestInjectArgs(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	received := make(chan map[string]string, 1)
+	server.HandleCall("traced", func(req *mockserver.Request) ([]interface{}, error) {
+		var args []interface{}
+		if err := req.Decode(iproto.IPROTO_TUPLE, &args); err != nil {
+			return nil, err
+		}
+		carrier := map[string]string{}
+		if m, ok := args[len(args)-1].(map[string]interface{}); ok {
+			for k, v := range m {
+				carrier[k], _ = v.(string)
+			}
+		}
+		received <- carrier
+		return nil, nil
+	})
+
+	provider, _ := newProvider()
+	conn := server.Connect(t, tarantool.Opts{})
+
+	spanCtx, span := provider.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+
+	args := tntotel.InjectArgs(spanCtx, []interface{}{1, "foo"})
+	require.Len(t, args, 3)
+	_, err := conn.Do(tarantool.NewCallRequest("traced").Args(args)).Get()
+	require.NoError(t, err)
+
+	carrier := <-received
+	assert.Contains(t, carrier["traceparent"], span.SpanContext().TraceID().String())
+}
@@ -0,0 +1,131 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tarantool/go-tarantool/v2/box"
+)
+
+// InstancesError is returned by pool helpers if requests to some instances
+// of a pool failed.
+type InstancesError struct {
+	// Errors are errors per instance name.
+	Errors map[string]error
+}
+
+// Error returns errors of instances sorted by instance names.
+func (e *InstancesError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", name, e.Errors[name]))
+	}
+	return "failed on instances: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns errors of instances.
+func (e *InstancesError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Backup is a backup started on instances of a pool.
+type Backup struct {
+	pool *ConnectionPool
+	// names are names of instances where the backup is requested to start.
+	names []string
+	// Files are files to copy per instance name. It contains only instances
+	// where the backup is started.
+	Files map[string][]string
+}
+
+// StartBackup starts a backup of the n-th checkpoint before the last one on
+// every instance of the pool with box.BackupStartRequest. Files of the backup
+// are kept on the instances until Backup.Stop() is called.
+//
+// If the backup fails to start on some instances, it returns the backup
+// started on other instances and a *InstancesError. The backup must be
+// stopped anyway. It returns ErrNoHealthyInstance if the pool has no
+// instances.
+func StartBackup(ctx context.Context, p *ConnectionPool, n uint) (*Backup, error) {
+	backup := &Backup{
+		pool:  p,
+		Files: make(map[string][]string),
+	}
+
+	var names []string
+	for name := range p.GetInfo() {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, ErrNoHealthyInstance
+	}
+	backup.names = names
+
+	var mutex sync.Mutex
+	err := doInstances(names, func(name string) error {
+		var resp box.BackupStartResponse
+		req := box.NewBackupStartRequest(n).Context(ctx)
+		if err := p.DoInstance(req, name).GetTyped(&resp); err != nil {
+			return err
+		}
+		mutex.Lock()
+		backup.Files[name] = resp.Files
+		mutex.Unlock()
+		return nil
+	})
+	return backup, err
+}
+
+// Stop stops the backup on all instances where it is requested to start:
+// the backup could be started even if a response is not received. It
+// returns a *InstancesError if the backup fails to stop on some instances where
+// it is started. Errors of other instances are ignored.
+func (b *Backup) Stop(ctx context.Context) error {
+	return doInstances(b.names, func(name string) error {
+		_, err := b.pool.DoInstance(box.NewBackupStopRequest().Context(ctx), name).Get()
+		if _, ok := b.Files[name]; !ok {
+			// The backup is not started or it is unknown.
+			return nil
+		}
+		return err
+	})
+}
+
+// doInstances calls f for instances concurrently and collects errors.
+func doInstances(names []string, f func(name string) error) error {
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		errs  = make(map[string]error)
+	)
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			if err := f(name); err != nil {
+				mutex.Lock()
+				errs[name] = err
+				mutex.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &InstancesError{Errors: errs}
+	}
+	return nil
+}
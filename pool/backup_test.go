@@ -0,0 +1,89 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestStartBackup(t *testing.T) {
+	var (
+		mutex     sync.Mutex
+		instances []pool.Instance
+		stopped   []string
+	)
+	for _, name := range []string{"master", "replica", "broken"} {
+		server := mockserver.StartTest(t, mockserver.Opts{})
+
+		name := name
+		server.HandleCall("box.backup.start",
+			func(req *mockserver.Request) ([]interface{}, error) {
+				if name == "broken" {
+					return nil, tarantool.Error{
+						Code: iproto.ER_BACKUP_IN_PROGRESS,
+						Msg:  "Backup is already in progress",
+					}
+				}
+				return []interface{}{[]string{"/" + name + "/00000000000000000008.snap"}}, nil
+			})
+		server.HandleCall("box.backup.stop",
+			func(req *mockserver.Request) ([]interface{}, error) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				stopped = append(stopped, name)
+				if name == "broken" {
+					return nil, tarantool.Error{
+						Code: iproto.ER_PROC_LUA,
+						Msg:  "Backup is not started",
+					}
+				}
+				return nil, nil
+			})
+
+		instances = append(instances, pool.Instance{
+			Name:   name,
+			Dialer: tarantool.NetDialer{Address: server.Addr()},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, instances, pool.Opts{
+		CheckTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	backup, err := pool.StartBackup(ctx, connPool, 0)
+	require.NotNil(t, backup)
+	assert.Equal(t, map[string][]string{
+		"master":  {"/master/00000000000000000008.snap"},
+		"replica": {"/replica/00000000000000000008.snap"},
+	}, backup.Files)
+
+	var instancesErr *pool.InstancesError
+	require.ErrorAs(t, err, &instancesErr)
+	require.Len(t, instancesErr.Errors, 1)
+	assert.Contains(t, err.Error(),
+		"failed on instances: broken: Backup is already in progress")
+	var tntErr tarantool.Error
+	require.True(t, errors.As(err, &tntErr))
+	assert.Equal(t, iproto.ER_BACKUP_IN_PROGRESS, tntErr.Code)
+
+	// The backup is stopped on the broken instance too, but its error is
+	// ignored.
+	require.NoError(t, backup.Stop(ctx))
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.ElementsMatch(t, []string{"master", "replica", "broken"}, stopped)
+}
@@ -0,0 +1,54 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestConnectionPool_NewBatch(t *testing.T) {
+	var instances []pool.Instance
+	for _, name := range []string{"first", "second"} {
+		server := mockserver.StartTest(t, mockserver.Opts{})
+
+		name := name
+		server.HandleCall("whoami", func(req *mockserver.Request) ([]interface{}, error) {
+			return []interface{}{name}, nil
+		})
+		instances = append(instances, pool.Instance{
+			Name:   name,
+			Dialer: tarantool.NetDialer{Address: server.Addr()},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, instances, pool.Opts{
+		CheckTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	batch, err := connPool.NewBatch("second")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		batch.Add(tarantool.NewCallRequest("whoami"))
+	}
+	futures := batch.Send()
+	require.NoError(t, batch.WaitAll())
+	for _, fut := range futures {
+		data, err := fut.Get()
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"second"}, data)
+	}
+
+	_, err = connPool.NewBatch("unknown")
+	assert.ErrorIs(t, err, pool.ErrNoHealthyInstance)
+}
@@ -0,0 +1,71 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestConnectionPool_CircuitBreaker(t *testing.T) {
+	var instances []pool.Instance
+	for _, name := range []string{"hung", "ready"} {
+		server := mockserver.StartTest(t, mockserver.Opts{})
+
+		name := name
+		server.HandleCall("whoami", func(req *mockserver.Request) ([]interface{}, error) {
+			if name == "hung" {
+				return nil, mockserver.ErrNoResponse
+			}
+			return []interface{}{name}, nil
+		})
+		if name == "hung" {
+			server.Handle(iproto.IPROTO_PING, func(req *mockserver.Request) ([]interface{}, error) {
+				return nil, mockserver.ErrNoResponse
+			})
+		}
+		instances = append(instances, pool.Instance{
+			Name:   name,
+			Dialer: tarantool.NetDialer{Address: server.Addr()},
+			Opts: tarantool.Opts{
+				SkipSchema: true,
+				Timeout:    50 * time.Millisecond,
+				Reconnect:  10 * time.Millisecond,
+				CircuitBreaker: &tarantool.CircuitBreaker{
+					MinRequests:  2,
+					TimeoutRatio: 0.5,
+					OpenTimeout:  time.Hour,
+				},
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, instances, pool.Opts{
+		CheckTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	for i := 0; i < 8; i++ {
+		connPool.Do(tarantool.NewCallRequest("whoami"), pool.ANY).Get()
+	}
+	info := connPool.GetInfo()
+	assert.NotEqual(t, tarantool.CircuitClosed, info["hung"].CircuitState)
+	assert.Equal(t, tarantool.CircuitClosed, info["ready"].CircuitState)
+
+	// The instance with the open circuit is skipped.
+	for i := 0; i < 4; i++ {
+		data, err := connPool.Do(tarantool.NewCallRequest("whoami"), pool.ANY).Get()
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"ready"}, data)
+	}
+}
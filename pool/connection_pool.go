@@ -63,6 +63,14 @@ type ConnectionHandler interface {
 	Deactivated(name string, conn *tarantool.Connection, role Role) error
 }
 
+// Metrics is the interface to collect metrics of a ConnectionPool. Metrics of
+// connections could be collected with tarantool.Opts.Metrics of instances.
+type Metrics interface {
+	// RoleChanged is called when a role of an instance has been detected
+	// for the first time or has been changed.
+	RoleChanged(name string, from Role, to Role)
+}
+
 // Instance describes a single instance configuration in the pool.
 type Instance struct {
 	// Name is an instance name. The name must be unique.
@@ -81,6 +89,24 @@ type Opts struct {
 	CheckTimeout time.Duration
 	// ConnectionHandler provides an ability to handle connection updates.
 	ConnectionHandler ConnectionHandler
+	// Metrics is used to collect metrics of the pool. It is disabled by
+	// default.
+	Metrics Metrics
+	// Tracer is used to trace requests of the pool. It is disabled by
+	// default.
+	Tracer Tracer
+	// Interceptors intercept requests passed to ConnectionPool.Do() and
+	// ConnectionPool.DoInstance(). The first interceptor is the outermost
+	// one. The next Doer chooses a connection on each call, so an
+	// interceptor could send a request again to another instance.
+	// Interceptors from instance options are called after the pool ones.
+	Interceptors []tarantool.Interceptor
+	// RetryPolicy is used to retry failed idempotent requests passed to
+	// ConnectionPool.Do() and ConnectionPool.DoInstance(). A request sent
+	// with a mode could be retried on another instance. Interceptors are
+	// called once for a request, before retries. Retries are disabled by
+	// default.
+	RetryPolicy *tarantool.RetryPolicy
 }
 
 /*
@@ -89,10 +115,18 @@ ConnectionInfo structure for information about connection statuses:
 - ConnectedNow reports if connection is established at the moment.
 
 - ConnRole reports master/replica role of instance.
+
+- RateLimit reports the current limit of 'in-fly' requests of the connection,
+see tarantool.Connection.RateLimit().
+
+- CircuitState reports a state of the circuit breaker of the connection,
+see tarantool.Connection.CircuitState().
 */
 type ConnectionInfo struct {
 	ConnectedNow bool
 	ConnRole     Role
+	RateLimit    uint
+	CircuitState tarantool.CircuitState
 }
 
 /*
@@ -396,7 +430,12 @@ func (p *ConnectionPool) GetInfo() map[string]ConnectionInfo {
 	for name := range p.ends {
 		conn, role := p.getConnectionFromPool(name)
 		if conn != nil {
-			info[name] = ConnectionInfo{ConnectedNow: conn.ConnectedNow(), ConnRole: role}
+			info[name] = ConnectionInfo{
+				ConnectedNow: conn.ConnectedNow(),
+				ConnRole:     role,
+				RateLimit:    conn.RateLimit(),
+				CircuitState: conn.CircuitState(),
+			}
 		} else {
 			info[name] = ConnectionInfo{ConnectedNow: false, ConnRole: UnknownRole}
 		}
@@ -923,6 +962,16 @@ func (p *ConnectionPool) NewStream(userMode Mode) (*tarantool.Stream, error) {
 	return conn.NewStream()
 }
 
+// NewBatch creates a new batch of requests for the instance with the name.
+// Requests of the batch are sent with a single flush.
+func (p *ConnectionPool) NewBatch(name string) (*tarantool.Batch, error) {
+	conn := p.anyPool.GetConnection(name)
+	if conn == nil {
+		return nil, ErrNoHealthyInstance
+	}
+	return conn.NewBatch(), nil
+}
+
 // NewPrepared passes a sql statement to Tarantool for preparation synchronously.
 func (p *ConnectionPool) NewPrepared(expr string, userMode Mode) (*tarantool.Prepared, error) {
 	conn, err := p.getNextConnection(userMode)
@@ -988,6 +1037,14 @@ func (p *ConnectionPool) NewWatcher(key string,
 // For requests that belong to the only one connection (e.g. Unprepare or ExecutePrepared)
 // the argument of type Mode is unused.
 func (p *ConnectionPool) Do(req tarantool.Request, userMode Mode) *tarantool.Future {
+	return p.intercept(req, func(req tarantool.Request) *tarantool.Future {
+		return p.do(req, userMode)
+	})
+}
+
+// do sends the request on a connection chosen by the mode without
+// interceptors.
+func (p *ConnectionPool) do(req tarantool.Request, userMode Mode) *tarantool.Future {
 	if connectedReq, ok := req.(tarantool.ConnectedRequest); ok {
 		conns := p.anyPool.GetConnections()
 		isOurConnection := false
@@ -999,13 +1056,13 @@ func (p *ConnectionPool) Do(req tarantool.Request, userMode Mode) *tarantool.Fut
 			}
 		}
 		if !isOurConnection {
-			return newErrorFuture(ErrUnknownRequest)
+			return p.traceError(req, "", ErrUnknownRequest)
 		}
 		return connectedReq.Conn().Do(req)
 	}
 	conn, err := p.getNextConnection(userMode)
 	if err != nil {
-		return newErrorFuture(err)
+		return p.traceError(req, "", err)
 	}
 
 	return conn.Do(req)
@@ -1013,9 +1070,17 @@ func (p *ConnectionPool) Do(req tarantool.Request, userMode Mode) *tarantool.Fut
 
 // DoInstance sends the request into a target instance and returns a future.
 func (p *ConnectionPool) DoInstance(req tarantool.Request, name string) *tarantool.Future {
+	return p.intercept(req, func(req tarantool.Request) *tarantool.Future {
+		return p.doInstance(req, name)
+	})
+}
+
+// doInstance sends the request into the target instance without
+// interceptors.
+func (p *ConnectionPool) doInstance(req tarantool.Request, name string) *tarantool.Future {
 	conn := p.anyPool.GetConnection(name)
 	if conn == nil {
-		return newErrorFuture(ErrNoHealthyInstance)
+		return p.traceError(req, name, ErrNoHealthyInstance)
 	}
 
 	return conn.Do(req)
@@ -1171,6 +1236,28 @@ func (p *ConnectionPool) handlerDeactivated(name string, conn *tarantool.Connect
 	}
 }
 
+// intercept passes the request through the interceptors and the retry
+// policy to the last Doer.
+func (p *ConnectionPool) intercept(req tarantool.Request,
+	last tarantool.DoerFunc) *tarantool.Future {
+	if policy := p.opts.RetryPolicy; policy != nil {
+		do := last
+		last = func(req tarantool.Request) *tarantool.Future {
+			return policy.Intercept(req, do)
+		}
+	}
+	if len(p.opts.Interceptors) != 0 {
+		return tarantool.ChainInterceptors(p.opts.Interceptors...)(req, last)
+	}
+	return last(req)
+}
+
+func (p *ConnectionPool) roleChanged(name string, from Role, to Role) {
+	if p.opts.Metrics != nil {
+		p.opts.Metrics.RoleChanged(name, from, to)
+	}
+}
+
 func (p *ConnectionPool) deactivateConnection(name string,
 	conn *tarantool.Connection, role Role) {
 	p.deleteConnection(name)
@@ -1220,7 +1307,15 @@ func (p *ConnectionPool) updateConnection(e *endpoint) {
 		return
 	}
 
-	if role, err := p.getConnectionRole(e.conn); err == nil {
+	role, err := p.getConnectionRole(e.conn)
+	var clientErr tarantool.ClientError
+	if errors.As(err, &clientErr) && clientErr.Code == tarantool.ErrCircuitOpen {
+		// The circuit breaker probes the instance by itself, the connection
+		// is skipped until the circuit is closed.
+		p.poolsMutex.Unlock()
+		return
+	}
+	if err == nil {
 		if e.role != role {
 			p.deleteConnection(e.name)
 			p.poolsMutex.Unlock()
@@ -1254,6 +1349,7 @@ func (p *ConnectionPool) updateConnection(e *endpoint) {
 				e.role = UnknownRole
 				return
 			}
+			p.roleChanged(e.name, e.role, role)
 			e.role = role
 		}
 		p.poolsMutex.Unlock()
@@ -1283,6 +1379,9 @@ func (p *ConnectionPool) tryConnect(ctx context.Context, e *endpoint) error {
 
 	connOpts := e.opts
 	connOpts.Notify = e.notify
+	if p.opts.Tracer != nil {
+		connOpts.Tracer = instanceTracer{pool: p, name: e.name}
+	}
 	conn, err := tarantool.Connect(ctx, e.dialer, connOpts)
 	if err == nil {
 		role, err := p.getConnectionRole(conn)
@@ -1317,6 +1416,7 @@ func (p *ConnectionPool) tryConnect(ctx context.Context, e *endpoint) error {
 		}
 		e.conn = conn
 		e.role = role
+		p.roleChanged(e.name, UnknownRole, role)
 	}
 
 	p.poolsMutex.Unlock()
@@ -0,0 +1,74 @@
+package pool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestConnectionPool_Interceptors(t *testing.T) {
+	var instances []pool.Instance
+	for _, name := range []string{"first", "second"} {
+		server := mockserver.StartTest(t, mockserver.Opts{})
+
+		name := name
+		server.HandleCall("whoami", func(req *mockserver.Request) ([]interface{}, error) {
+			return []interface{}{name}, nil
+		})
+		instances = append(instances, pool.Instance{
+			Name:   name,
+			Dialer: tarantool.NetDialer{Address: server.Addr()},
+		})
+	}
+
+	var mutex sync.Mutex
+	var answers []interface{}
+	// retry sends a call request twice, so the pool chooses both instances.
+	retry := func(req tarantool.Request, next tarantool.Doer) *tarantool.Future {
+		if req.Type() != iproto.IPROTO_CALL {
+			return next.Do(req)
+		}
+		for i := 0; i < 2; i++ {
+			data, err := next.Do(req).Get()
+			require.NoError(t, err)
+			mutex.Lock()
+			answers = append(answers, data...)
+			mutex.Unlock()
+		}
+		return next.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, instances, pool.Opts{
+		CheckTimeout: 5 * time.Second,
+		Interceptors: []tarantool.Interceptor{retry},
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	_, err = connPool.Do(tarantool.NewCallRequest("whoami"), pool.ANY).Get()
+	require.NoError(t, err)
+
+	mutex.Lock()
+	assert.ElementsMatch(t, []interface{}{"first", "second"}, answers)
+	answers = nil
+	mutex.Unlock()
+
+	data, err := connPool.DoInstance(tarantool.NewCallRequest("whoami"), "second").Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"second"}, data)
+
+	mutex.Lock()
+	assert.Equal(t, []interface{}{"second", "second"}, answers)
+	mutex.Unlock()
+}
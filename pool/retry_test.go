@@ -0,0 +1,61 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+func TestConnectionPool_RetryPolicy(t *testing.T) {
+	var instances []pool.Instance
+	for _, name := range []string{"loading", "ready"} {
+		server := mockserver.StartTest(t, mockserver.Opts{})
+
+		name := name
+		server.HandleCall("whoami", func(req *mockserver.Request) ([]interface{}, error) {
+			if name == "loading" {
+				return nil, tarantool.Error{Code: iproto.ER_LOADING, Msg: "loading"}
+			}
+			return []interface{}{name}, nil
+		})
+		instances = append(instances, pool.Instance{
+			Name:   name,
+			Dialer: tarantool.NetDialer{Address: server.Addr()},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, instances, pool.Opts{
+		CheckTimeout: 5 * time.Second,
+		RetryPolicy: &tarantool.RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	// Every request is either sent to the ready instance at once or retried
+	// on it after the loading one.
+	for i := 0; i < 4; i++ {
+		req := tarantool.NewCallRequest("whoami").Idempotent(true)
+		data, err := connPool.Do(req, pool.ANY).Get()
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"ready"}, data)
+	}
+
+	_, err = connPool.DoInstance(tarantool.NewCallRequest("whoami").Idempotent(true),
+		"loading").Get()
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_LOADING, tntErr.Code)
+}
@@ -78,7 +78,14 @@ func (r *roundRobinStrategy) GetNextConnection() *tarantool.Connection {
 	if r.size == 0 {
 		return nil
 	}
-	return r.conns[r.nextIndex()]
+	// Skip instances with an open circuit breaker.
+	for i := uint64(0); i < r.size; i++ {
+		conn := r.conns[r.nextIndex()]
+		if conn.CircuitState() == tarantool.CircuitClosed {
+			return conn
+		}
+	}
+	return nil
 }
 
 func (r *roundRobinStrategy) GetConnections() map[string]*tarantool.Connection {
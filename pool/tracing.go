@@ -0,0 +1,66 @@
+package pool
+
+import (
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// TraceInfo describes a request passed to a Tracer.
+type TraceInfo struct {
+	tarantool.TraceInfo
+	// Instance is a name of the instance chosen to execute the request. It
+	// is empty if the pool has failed to choose an instance by a mode.
+	Instance string
+	// Role is a role of the instance at the moment the request is sent.
+	Role Role
+}
+
+// Tracer is the interface to trace requests of a ConnectionPool. It could be
+// set with Opts.Tracer.
+//
+// The pool tracer is set as tarantool.Opts.Tracer for connections to all
+// instances, so it overrides tracers from instance options. Requests that
+// the pool sends by itself, such as checks of instance roles, are traced
+// too.
+type Tracer interface {
+	// StartTrace is called when a request is passed to a connection of the
+	// pool or the pool fails to choose a connection. If it returns a non-nil
+	// function, the function is called exactly once when the request is
+	// done.
+	StartTrace(info TraceInfo) tarantool.TraceFinish
+}
+
+// instanceTracer adds information about a pool instance to traces of its
+// connection.
+type instanceTracer struct {
+	pool *ConnectionPool
+	name string
+}
+
+// StartTrace makes instanceTracer satisfy the tarantool.Tracer interface.
+func (t instanceTracer) StartTrace(info tarantool.TraceInfo) tarantool.TraceFinish {
+	_, role := t.pool.getConnectionFromPool(t.name)
+	return t.pool.opts.Tracer.StartTrace(TraceInfo{
+		TraceInfo: info,
+		Instance:  t.name,
+		Role:      role,
+	})
+}
+
+// traceError traces a request that is failed before passing it to a
+// connection.
+func (p *ConnectionPool) traceError(req tarantool.Request, name string,
+	err error) *tarantool.Future {
+	if p.opts.Tracer != nil {
+		finish := p.opts.Tracer.StartTrace(TraceInfo{
+			TraceInfo: tarantool.TraceInfo{
+				Request: req,
+				Ctx:     req.Ctx(),
+			},
+			Instance: name,
+		})
+		if finish != nil {
+			finish(nil, err)
+		}
+	}
+	return newErrorFuture(err)
+}
@@ -0,0 +1,266 @@
+// Package prometheus provides a Prometheus collector for metrics of
+// connections and connection pools.
+//
+// The package is a separate Go module, so the connector does not depend on
+// the Prometheus client library.
+//
+// Usage:
+//
+//	collector := prometheus.NewCollector(prometheus.Opts{})
+//	registry.MustRegister(collector)
+//
+//	opts := tarantool.Opts{Metrics: collector}
+//	conn, err := tarantool.Connect(ctx, dialer, opts)
+//
+//	poolOpts := pool.Opts{CheckTimeout: time.Second, Metrics: collector}
+//	connPool, err := pool.ConnectWithOpts(ctx, instances, poolOpts)
+package prometheus
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+)
+
+const defaultNamespace = "tarantool"
+
+const (
+	addrLabel     = "addr"
+	typeLabel     = "type"
+	sourceLabel   = "source"
+	codeLabel     = "code"
+	eventLabel    = "event"
+	instanceLabel = "instance"
+	fromLabel     = "from"
+	toLabel       = "to"
+	roleLabel     = "role"
+)
+
+const (
+	// sourceClient is a label value for client-side errors (ClientError).
+	sourceClient = "client"
+	// sourceServer is a label value for errors from Tarantool.
+	sourceServer = "server"
+	// sourceOther is a label value for other errors.
+	sourceOther = "other"
+)
+
+// Opts configures a Collector.
+type Opts struct {
+	// Namespace is a namespace of metrics. By default, it is "tarantool".
+	Namespace string
+	// Buckets are buckets of request latency histograms in seconds. By
+	// default, prometheus.DefBuckets are used.
+	Buckets []float64
+	// ConstLabels are labels added to all metrics.
+	ConstLabels prom.Labels
+}
+
+// Collector collects metrics of connections and connection pools. It
+// implements tarantool.Metrics, pool.Metrics and prometheus.Collector
+// interfaces.
+type Collector struct {
+	inflight     *prom.GaugeVec
+	latency      *prom.HistogramVec
+	timeouts     *prom.CounterVec
+	errors       *prom.CounterVec
+	events       *prom.CounterVec
+	reconnects   *prom.CounterVec
+	bytesRead    *prom.CounterVec
+	bytesWritten *prom.CounterVec
+	roleChanges  *prom.CounterVec
+	roles        *prom.GaugeVec
+
+	// connected contains connections that have been connected at least once.
+	connected sync.Map
+}
+
+var _ tarantool.Metrics = (*Collector)(nil)
+var _ pool.Metrics = (*Collector)(nil)
+var _ prom.Collector = (*Collector)(nil)
+
+// NewCollector creates a new Collector.
+func NewCollector(opts Opts) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = defaultNamespace
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prom.DefBuckets
+	}
+
+	counter := func(name, help string, labels ...string) *prom.CounterVec {
+		return prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: opts.ConstLabels,
+		}, labels)
+	}
+	gauge := func(name, help string, labels ...string) *prom.GaugeVec {
+		return prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: opts.ConstLabels,
+		}, labels)
+	}
+
+	return &Collector{
+		inflight: gauge("requests_in_flight",
+			"Count of requests in flight.", addrLabel),
+		latency: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of requests by a request type.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.Buckets,
+		}, []string{addrLabel, typeLabel}),
+		timeouts: counter("request_timeouts_total",
+			"Count of timed out requests.", addrLabel, typeLabel),
+		errors: counter("request_errors_total",
+			"Count of failed requests by an error code.",
+			addrLabel, typeLabel, sourceLabel, codeLabel),
+		events: counter("connection_events_total",
+			"Count of connection events.", addrLabel, eventLabel),
+		reconnects: counter("reconnects_total",
+			"Count of successful reconnects.", addrLabel),
+		bytesRead: counter("read_bytes_total",
+			"Count of bytes read from the network.", addrLabel),
+		bytesWritten: counter("written_bytes_total",
+			"Count of bytes written to the network.", addrLabel),
+		roleChanges: counter("pool_role_changes_total",
+			"Count of role changes of pool instances.",
+			instanceLabel, fromLabel, toLabel),
+		roles: gauge("pool_instance_role",
+			"Current role of a pool instance: 1 for the current role.",
+			instanceLabel, roleLabel),
+	}
+}
+
+func (c *Collector) collectors() []prom.Collector {
+	return []prom.Collector{
+		c.inflight,
+		c.latency,
+		c.timeouts,
+		c.errors,
+		c.events,
+		c.reconnects,
+		c.bytesRead,
+		c.bytesWritten,
+		c.roleChanges,
+		c.roles,
+	}
+}
+
+// Describe makes Collector satisfy the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect makes Collector satisfy the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// RequestStarted makes Collector satisfy the tarantool.Metrics interface.
+func (c *Collector) RequestStarted(conn *tarantool.Connection, rtype iproto.Type) {
+	c.inflight.WithLabelValues(connAddr(conn)).Inc()
+}
+
+// RequestDone makes Collector satisfy the tarantool.Metrics interface.
+func (c *Collector) RequestDone(conn *tarantool.Connection, rtype iproto.Type,
+	elapsed time.Duration, err error) {
+	addr := connAddr(conn)
+	rtypeName := rtype.String()
+
+	c.inflight.WithLabelValues(addr).Dec()
+	c.latency.WithLabelValues(addr, rtypeName).Observe(elapsed.Seconds())
+
+	if err == nil {
+		return
+	}
+
+	var clientErr tarantool.ClientError
+	var tntErr tarantool.Error
+	var boxErr *tarantool.BoxError
+	source, code := sourceOther, ""
+	switch {
+	case errors.As(err, &clientErr):
+		source, code = sourceClient, "0x"+strconv.FormatUint(uint64(clientErr.Code), 16)
+		if clientErr.Code == tarantool.ErrTimeouted {
+			c.timeouts.WithLabelValues(addr, rtypeName).Inc()
+		}
+	case errors.As(err, &tntErr):
+		source, code = sourceServer, tntErr.Code.String()
+	case errors.As(err, &boxErr):
+		source, code = sourceServer, iproto.Error(boxErr.Code).String()
+	}
+	c.errors.WithLabelValues(addr, rtypeName, source, code).Inc()
+}
+
+// ConnectionEvent makes Collector satisfy the tarantool.Metrics interface.
+func (c *Collector) ConnectionEvent(conn *tarantool.Connection,
+	kind tarantool.ConnEventKind) {
+	addr := connAddr(conn)
+	c.events.WithLabelValues(addr, eventName(kind)).Inc()
+
+	switch kind {
+	case tarantool.Connected:
+		if _, loaded := c.connected.LoadOrStore(conn, struct{}{}); loaded {
+			c.reconnects.WithLabelValues(addr).Inc()
+		}
+	case tarantool.Closed:
+		c.connected.Delete(conn)
+	}
+}
+
+// BytesRead makes Collector satisfy the tarantool.Metrics interface.
+func (c *Collector) BytesRead(conn *tarantool.Connection, n int) {
+	c.bytesRead.WithLabelValues(connAddr(conn)).Add(float64(n))
+}
+
+// BytesWritten makes Collector satisfy the tarantool.Metrics interface.
+func (c *Collector) BytesWritten(conn *tarantool.Connection, n int) {
+	c.bytesWritten.WithLabelValues(connAddr(conn)).Add(float64(n))
+}
+
+// RoleChanged makes Collector satisfy the pool.Metrics interface.
+func (c *Collector) RoleChanged(name string, from pool.Role, to pool.Role) {
+	c.roleChanges.WithLabelValues(name, from.String(), to.String()).Inc()
+	c.roles.WithLabelValues(name, from.String()).Set(0)
+	c.roles.WithLabelValues(name, to.String()).Set(1)
+}
+
+func connAddr(conn *tarantool.Connection) string {
+	if addr := conn.Addr(); addr != nil {
+		return addr.String()
+	}
+	return ""
+}
+
+func eventName(kind tarantool.ConnEventKind) string {
+	switch kind {
+	case tarantool.Connected:
+		return "connected"
+	case tarantool.Disconnected:
+		return "disconnected"
+	case tarantool.ReconnectFailed:
+		return "reconnect_failed"
+	case tarantool.Shutdown:
+		return "shutdown"
+	case tarantool.Closed:
+		return "closed"
+	}
+	return "unknown"
+}
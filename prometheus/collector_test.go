@@ -0,0 +1,166 @@
+package prometheus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/prometheus"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const timeout = 5 * time.Second
+
+// value returns a sum of values of metrics with the name and the labels.
+func value(t *testing.T, collector *prometheus.Collector, name string,
+	labels map[string]string) float64 {
+	t.Helper()
+
+	registry := prom.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	sum := 0.0
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if !matchLabels(m, labels) {
+				continue
+			}
+			switch {
+			case m.Counter != nil:
+				sum += m.Counter.GetValue()
+			case m.Gauge != nil:
+				sum += m.Gauge.GetValue()
+			case m.Histogram != nil:
+				sum += float64(m.Histogram.GetSampleCount())
+			}
+		}
+	}
+	return sum
+}
+
+func matchLabels(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range m.GetLabel() {
+		if expected, ok := labels[pair.GetName()]; ok {
+			if expected != pair.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func TestCollector_Connection(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("fail", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, tarantool.Error{Code: iproto.ER_PROC_LUA, Msg: "fail"}
+	})
+	server.HandleCall("hang", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, mockserver.ErrNoResponse
+	})
+
+	collector := prometheus.NewCollector(prometheus.Opts{})
+
+	conn := server.Connect(t, tarantool.Opts{
+		Timeout:       500 * time.Millisecond,
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		Metrics:       collector,
+	})
+
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	require.NoError(t, err)
+	_, err = conn.Do(tarantool.NewCallRequest("fail")).Get()
+	require.Error(t, err)
+
+	_, err = conn.Do(tarantool.NewCallRequest("hang")).Get()
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = conn.Do(tarantool.NewPingRequest().Context(ctx)).Get()
+	require.Error(t, err)
+
+	addr := conn.Addr().String()
+	// The connection sends pings in background.
+	assert.Eventually(t, func() bool {
+		return value(t, collector, "tarantool_requests_in_flight", nil) == 0.0
+	}, timeout, 10*time.Millisecond)
+	assert.Equal(t, 1.0, value(t, collector, "tarantool_request_errors_total",
+		map[string]string{
+			"addr":   addr,
+			"type":   "IPROTO_CALL",
+			"source": "server",
+			"code":   "ER_PROC_LUA",
+		}))
+	assert.Equal(t, 1.0, value(t, collector, "tarantool_request_timeouts_total",
+		map[string]string{"addr": addr, "type": "IPROTO_CALL"}))
+	assert.GreaterOrEqual(t, value(t, collector, "tarantool_request_duration_seconds",
+		map[string]string{"addr": addr, "type": "IPROTO_PING"}), 1.0)
+	assert.Greater(t, value(t, collector, "tarantool_read_bytes_total",
+		map[string]string{"addr": addr}), 0.0)
+	assert.Greater(t, value(t, collector, "tarantool_written_bytes_total",
+		map[string]string{"addr": addr}), 0.0)
+
+	server.DropConnections()
+	require.Eventually(t, func() bool {
+		return value(t, collector, "tarantool_reconnects_total",
+			map[string]string{"addr": addr}) == 1.0
+	}, timeout, 10*time.Millisecond)
+}
+
+func TestCollector_Pool(t *testing.T) {
+	rw := mockserver.StartTest(t, mockserver.Opts{})
+	ro := mockserver.StartTest(t, mockserver.Opts{ReadOnly: true})
+
+	collector := prometheus.NewCollector(prometheus.Opts{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	connPool, err := pool.ConnectWithOpts(ctx, []pool.Instance{
+		{
+			Name:   "rw",
+			Dialer: tarantool.NetDialer{Address: rw.Addr()},
+			Opts:   tarantool.Opts{Timeout: timeout, Metrics: collector},
+		},
+		{
+			Name:   "ro",
+			Dialer: tarantool.NetDialer{Address: ro.Addr()},
+			Opts:   tarantool.Opts{Timeout: timeout, Metrics: collector},
+		},
+	}, pool.Opts{
+		CheckTimeout: 10 * time.Millisecond,
+		Metrics:      collector,
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	role := func(instance, role string) float64 {
+		return value(t, collector, "tarantool_pool_instance_role",
+			map[string]string{"instance": instance, "role": role})
+	}
+	assert.Equal(t, 1.0, role("rw", "master"))
+	assert.Equal(t, 1.0, role("ro", "replica"))
+
+	rw.SetReadOnly(true)
+	require.Eventually(t, func() bool {
+		return role("rw", "replica") == 1.0 && role("rw", "master") == 0.0
+	}, timeout, 10*time.Millisecond)
+
+	assert.Equal(t, 1.0, value(t, collector, "tarantool_pool_role_changes_total",
+		map[string]string{"instance": "rw", "from": "master", "to": "replica"}))
+}
@@ -867,6 +867,12 @@ func (req *spaceRequest) setSpace(space interface{}) {
 	req.space = space
 }
 
+// GetSpace returns the space of the request: a name, an ID or nil if it is
+// not set.
+func (req *spaceRequest) GetSpace() interface{} {
+	return req.space
+}
+
 func EncodeSpace(res SchemaResolver, enc *msgpack.Encoder, space interface{}) error {
 	spaceEnc, err := newSpaceEncoder(res, space)
 	if err != nil {
@@ -887,6 +893,12 @@ func (req *spaceIndexRequest) setIndex(index interface{}) {
 	req.index = index
 }
 
+// GetIndex returns the index of the request: a name, an ID or nil if it is
+// not set and the primary index is used.
+func (req *spaceIndexRequest) GetIndex() interface{} {
+	return req.index
+}
+
 // authRequest implements IPROTO_AUTH request.
 type authRequest struct {
 	auth       Auth
@@ -993,6 +1005,11 @@ func (req *PingRequest) Context(ctx context.Context) *PingRequest {
 	return req
 }
 
+// IsIdempotent returns true: a ping request could always be retried.
+func (req *PingRequest) IsIdempotent() bool {
+	return true
+}
+
 // SelectRequest allows you to create a select request object for execution
 // by a Connection.
 type SelectRequest struct {
@@ -1106,6 +1123,11 @@ func (req *SelectRequest) Context(ctx context.Context) *SelectRequest {
 	return req
 }
 
+// IsIdempotent returns true: a select request could always be retried.
+func (req *SelectRequest) IsIdempotent() bool {
+	return true
+}
+
 // Response creates a response for the SelectRequest.
 func (req *SelectRequest) Response(header Header, body io.Reader) (Response, error) {
 	baseResp, err := createBaseResponse(header, body)
@@ -1377,8 +1399,9 @@ func (req *UpsertRequest) Context(ctx context.Context) *UpsertRequest {
 // by a Connection.
 type CallRequest struct {
 	baseRequest
-	function string
-	args     interface{}
+	function   string
+	args       interface{}
+	idempotent bool
 }
 
 // NewCallRequest returns a new empty CallRequest. It uses request code for
@@ -1417,6 +1440,25 @@ func (req *CallRequest) Context(ctx context.Context) *CallRequest {
 	return req
 }
 
+// Idempotent marks the request as idempotent: the called function could be
+// executed several times safely, so the request could be retried by a
+// RetryPolicy.
+// Note: default value is false.
+func (req *CallRequest) Idempotent(idempotent bool) *CallRequest {
+	req.idempotent = idempotent
+	return req
+}
+
+// IsIdempotent returns true if the request is marked as idempotent.
+func (req *CallRequest) IsIdempotent() bool {
+	return req.idempotent
+}
+
+// GetFunction returns the name of the called function.
+func (req *CallRequest) GetFunction() string {
+	return req.function
+}
+
 // NewCall16Request returns a new empty Call16Request. It uses request code for
 // Tarantool 1.6.
 // Deprecated since Tarantool 1.7.2.
@@ -1498,6 +1540,11 @@ func (req *ExecuteRequest) Args(args interface{}) *ExecuteRequest {
 	return req
 }
 
+// GetExpr returns the SQL statement of the request.
+func (req *ExecuteRequest) GetExpr() string {
+	return req.expr
+}
+
 // Body fills an msgpack.Encoder with the execute request body.
 func (req *ExecuteRequest) Body(res SchemaResolver, enc *msgpack.Encoder) error {
 	return fillExecute(enc, req.expr, req.args)
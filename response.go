@@ -18,6 +18,16 @@ type Response interface {
 	DecodeTyped(res interface{}) error
 }
 
+// RawResponse is a Response with access to the undecoded body. All
+// responses created by the connector implement the interface.
+type RawResponse interface {
+	Response
+	// RawBody returns the undecoded IPROTO body of the response.
+	RawBody() []byte
+	// RawData returns msgpack encoded elements of IPROTO_DATA.
+	RawData() ([]msgpack.RawMessage, error)
+}
+
 type baseResponse struct {
 	// header is a response header.
 	header Header
@@ -194,6 +204,10 @@ func decodeHeader(d *msgpack.Decoder, buf *smallBuf) (Header, iproto.Type, error
 				return Header{}, 0, err
 			}
 			decodedHeader.RequestId = uint32(rid)
+		case iproto.IPROTO_SCHEMA_VERSION:
+			if decodedHeader.SchemaVersion, err = d.DecodeUint64(); err != nil {
+				return Header{}, 0, err
+			}
 		case iproto.IPROTO_REQUEST_TYPE:
 			if code, err = d.DecodeInt(); err != nil {
 				return Header{}, 0, err
@@ -669,6 +683,63 @@ func (resp *baseResponse) Header() Header {
 	return resp.header
 }
 
+// RawBody returns the undecoded IPROTO body of the response. The returned
+// slice refers to the response buffer and must not be modified.
+func (resp *baseResponse) RawBody() []byte {
+	return resp.buf.Bytes()
+}
+
+// RawData returns msgpack encoded elements of IPROTO_DATA without decoding,
+// for example, tuples of a select response. The returned slices refer to the
+// response buffer and must not be modified.
+//
+// It does not check an error of the response, see Future.GetRaw().
+func (resp *baseResponse) RawData() ([]msgpack.RawMessage, error) {
+	if resp.buf.Len() == 0 {
+		return nil, nil
+	}
+
+	// A copy of the buffer allows to keep the response state unchanged.
+	buf := resp.buf
+	d := msgpack.NewDecoder(&buf)
+
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return nil, err
+	}
+	for ; l > 0; l-- {
+		cd, err := smallInt(d, &buf)
+		if err != nil {
+			return nil, err
+		}
+		if iproto.Key(cd) != iproto.IPROTO_DATA {
+			if err := d.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		n, err := d.DecodeArrayLen()
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]msgpack.RawMessage, n)
+		for i := range data {
+			start := buf.Offset()
+			if err := d.Skip(); err != nil {
+				return nil, err
+			}
+			end := buf.Offset()
+			data[i] = buf.b[start:end:end]
+		}
+		return data, nil
+	}
+	return nil, nil
+}
+
 // Pos returns a position descriptor of the last selected tuple for the SelectResponse.
 // If the response was not decoded, this method will call Decode().
 func (resp *SelectResponse) Pos() ([]byte, error) {
@@ -53,3 +53,49 @@ func TestDecodeBaseResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseResponse_RawData(t *testing.T) {
+	tuples := []interface{}{
+		[]interface{}{uint64(1), "first"},
+		[]interface{}{uint64(2), "second"},
+	}
+	buf := bytes.NewBuffer([]byte{})
+	enc := msgpack.NewEncoder(buf)
+	require.NoError(t, enc.EncodeMapLen(2))
+	require.NoError(t, enc.EncodeUint8(uint8(iproto.IPROTO_POSITION)))
+	require.NoError(t, enc.EncodeBytes([]byte("pos")))
+	require.NoError(t, enc.EncodeUint8(uint8(iproto.IPROTO_DATA)))
+	require.NoError(t, enc.Encode(tuples))
+	body := append([]byte{}, buf.Bytes()...)
+
+	resp, err := tarantool.DecodeBaseResponse(tarantool.Header{}, buf)
+	require.NoError(t, err)
+	rawResp, ok := resp.(tarantool.RawResponse)
+	require.True(t, ok)
+	require.Equal(t, body, rawResp.RawBody())
+
+	data, err := rawResp.RawData()
+	require.NoError(t, err)
+	require.Len(t, data, len(tuples))
+	for i, tuple := range tuples {
+		expected, err := msgpack.Marshal(tuple)
+		require.NoError(t, err)
+		require.Equal(t, msgpack.RawMessage(expected), data[i])
+	}
+
+	// The response could be decoded after the raw access.
+	decoded, err := resp.Decode()
+	require.NoError(t, err)
+	require.Equal(t, tuples, decoded)
+	require.Equal(t, body, rawResp.RawBody())
+}
+
+func TestBaseResponse_RawData_empty(t *testing.T) {
+	resp, err := tarantool.DecodeBaseResponse(tarantool.Header{}, nil)
+	require.NoError(t, err)
+
+	data, err := resp.(tarantool.RawResponse).RawData()
+	require.NoError(t, err)
+	require.Nil(t, data)
+	require.Empty(t, resp.(tarantool.RawResponse).RawBody())
+}
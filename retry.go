@@ -0,0 +1,218 @@
+package tarantool
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/tarantool/go-iproto"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+)
+
+// DefaultRetryClientErrors are ClientError codes retried by a RetryPolicy
+// by default. ErrTimeouted is not retried by default: a timed out request
+// could still be in progress on a slow instance and retries add more load
+// to it. Add the code to RetryPolicy.ClientErrors to retry timeouts.
+var DefaultRetryClientErrors = []uint32{
+	ErrConnectionNotReady,
+	ErrConnectionClosed,
+	ErrConnectionShutdown,
+	ErrIoError,
+}
+
+// DefaultRetryBoxErrors are Tarantool error codes retried by a RetryPolicy
+// by default.
+var DefaultRetryBoxErrors = []iproto.Error{
+	iproto.ER_LOADING,
+	iproto.ER_READONLY,
+}
+
+// IdempotentRequest is an interface for requests that could be retried by a
+// RetryPolicy. SelectRequest and PingRequest are always idempotent,
+// CallRequest is idempotent if it is marked with CallRequest.Idempotent().
+type IdempotentRequest interface {
+	Request
+	// IsIdempotent returns true if the request could be performed several
+	// times safely.
+	IsIdempotent() bool
+}
+
+// RetryPolicy describes how to retry failed idempotent requests. It could
+// be set with Opts.RetryPolicy or pool.Opts.RetryPolicy.
+//
+// A request is retried if it implements IdempotentRequest and it has failed
+// with a retryable error. Delays between attempts grow exponentially from
+// InitialBackoff up to MaxBackoff. The request context, if any, interrupts
+// retries.
+//
+// Every attempt is performed with the next Doer as a separate request, so
+// it is traced with Opts.Tracer and counted with Opts.Metrics on its own.
+// Use OnRetry to observe retries.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum count of attempts including the first
+	// one. By default, it is 3.
+	MaxAttempts int
+	// InitialBackoff is a delay before the second attempt. By default, it is
+	// 100 milliseconds.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between attempts. By default, it is
+	// 5 seconds.
+	MaxBackoff time.Duration
+	// Jitter is a fraction of a delay in the range [0, 1] that is randomly
+	// subtracted from the delay. By default, there is no jitter.
+	Jitter float64
+	// ClientErrors are ClientError codes that are retried. By default,
+	// DefaultRetryClientErrors are used.
+	ClientErrors []uint32
+	// BoxErrors are Tarantool error codes that are retried. By default,
+	// DefaultRetryBoxErrors are used.
+	BoxErrors []iproto.Error
+	// OnRetry is called when an attempt has failed and the request is going
+	// to be retried after a backoff. It gets the request, a number of the
+	// failed attempt starting from 1 and its result. It is called from a
+	// goroutine of the policy and should not block.
+	OnRetry func(req Request, attempt int, resp Response, err error)
+}
+
+// Intercept performs the request with the next Doer and retries it
+// according to the policy. It makes RetryPolicy usable as an Interceptor:
+//
+//	opts.Interceptors = []tarantool.Interceptor{policy.Intercept}
+//
+// The future of the first attempt is returned as is. It is finished with a
+// result of the last attempt, push messages of all attempts are appended to
+// it.
+func (p *RetryPolicy) Intercept(req Request, next Doer) *Future {
+	fut := next.Do(req)
+	if !p.idempotent(req) || p.maxAttempts() <= 1 {
+		return fut
+	}
+
+	hooked := fut.setRetryHook(func(resp Response, err error) bool {
+		if !p.retryable(resp, err) {
+			return false
+		}
+		go p.retry(req, next, fut, resp, err)
+		return true
+	})
+	if hooked {
+		return fut
+	}
+
+	// The first attempt is already done.
+	resp, err := fut.GetResponse()
+	if !p.retryable(resp, err) {
+		return fut
+	}
+	result := NewFuture(req)
+	go p.retry(req, next, result, resp, err)
+	return result
+}
+
+// retry performs attempts after the first one and finishes the result
+// future with a result of the last attempt.
+func (p *RetryPolicy) retry(req Request, next Doer, result *Future,
+	resp Response, err error) {
+	for attempt := 1; ; attempt++ {
+		if attempt >= p.maxAttempts() || !p.retryable(resp, err) {
+			result.setResult(resp, err)
+			return
+		}
+		if p.OnRetry != nil {
+			p.OnRetry(req, attempt, resp, err)
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		if ctx := req.Ctx(); ctx != nil {
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				result.setResult(resp, err)
+				return
+			}
+		} else {
+			<-timer.C
+		}
+
+		fut := next.Do(req)
+		fut.forwardPushes(result)
+		resp, err = fut.GetResponse()
+	}
+}
+
+func (p *RetryPolicy) idempotent(req Request) bool {
+	if req.Async() {
+		return false
+	}
+	idempotentReq, ok := req.(IdempotentRequest)
+	return ok && idempotentReq.IsIdempotent()
+}
+
+func (p *RetryPolicy) retryable(resp Response, err error) bool {
+	if err != nil {
+		var clientErr ClientError
+		if !errors.As(err, &clientErr) {
+			return false
+		}
+		codes := p.ClientErrors
+		if codes == nil {
+			codes = DefaultRetryClientErrors
+		}
+		for _, code := range codes {
+			if code == clientErr.Code {
+				return true
+			}
+		}
+		return false
+	}
+
+	if resp == nil || resp.Header().Error == ErrorNo {
+		return false
+	}
+	codes := p.BoxErrors
+	if codes == nil {
+		codes = DefaultRetryBoxErrors
+	}
+	for _, code := range codes {
+		if code == resp.Header().Error {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts == 0 {
+		return defaultRetryMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns a delay after the attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max := p.InitialBackoff, p.MaxBackoff
+	if initial == 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	if max == 0 {
+		max = defaultRetryMaxBackoff
+	}
+
+	delay := initial
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
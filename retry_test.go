@@ -0,0 +1,283 @@
+package tarantool_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+var testRetryPolicy = &RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     50 * time.Millisecond,
+	Jitter:         0.5,
+}
+
+func TestRetryPolicy_reconnect(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			go server.DropConnections()
+			return nil, mockserver.ErrNoResponse
+		}
+		return []interface{}{[]interface{}{1}}, nil
+	})
+
+	conn := server.Connect(t, Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		RetryPolicy:   testRetryPolicy,
+	})
+
+	data, err := conn.Do(NewSelectRequest(512).Index(0)).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{int8(1)}}, data)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_call(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.HandleCall("loading", func(req *mockserver.Request) ([]interface{}, error) {
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			return nil, Error{Code: iproto.ER_LOADING, Msg: "loading"}
+		}
+		return []interface{}{true}, nil
+	})
+
+	conn := server.Connect(t, Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		RetryPolicy:   testRetryPolicy,
+	})
+
+	_, err := conn.Do(NewCallRequest("loading")).Get()
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	data, err := conn.Do(NewCallRequest("loading").Idempotent(true)).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{true}, data)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_maxAttempts(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.HandleCall("loading", func(req *mockserver.Request) ([]interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, Error{Code: iproto.ER_LOADING, Msg: "loading"}
+	})
+	server.HandleCall("fail", func(req *mockserver.Request) ([]interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, Error{Code: iproto.ER_PROC_LUA, Msg: "fail"}
+	})
+
+	conn := server.Connect(t, Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		RetryPolicy:   testRetryPolicy,
+	})
+
+	_, err := conn.Do(NewCallRequest("loading").Idempotent(true)).Get()
+	var tntErr Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_LOADING, tntErr.Code)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = conn.Do(NewCallRequest("fail").Idempotent(true)).Get()
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_PROC_LUA, tntErr.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_context(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.HandleCall("loading", func(req *mockserver.Request) ([]interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, Error{Code: iproto.ER_LOADING, Msg: "loading"}
+	})
+
+	conn := server.Connect(t, Opts{
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts:    100,
+			InitialBackoff: time.Hour,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := conn.Do(NewCallRequest("loading").Idempotent(true).Context(ctx)).Get()
+	var tntErr Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_LOADING, tntErr.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_Intercept_firstAttempt(t *testing.T) {
+	attempts := make(chan *Future, 3)
+	next := DoerFunc(func(req Request) *Future {
+		fut := NewFuture(req)
+		attempts <- fut
+		return fut
+	})
+
+	fut := testRetryPolicy.Intercept(NewPingRequest(), next)
+	first := <-attempts
+	assert.Same(t, first, fut)
+
+	first.SetError(ClientError{ErrConnectionClosed, "closed"})
+	second := <-attempts
+	select {
+	case <-fut.WaitChan():
+		t.Fatal("the future is done before the retry")
+	default:
+	}
+
+	second.SetError(ClientError{ErrConnectionClosed, "closed again"})
+	third := <-attempts
+	third.SetResponse(Header{}, nil)
+
+	_, err := fut.GetResponse()
+	require.NoError(t, err)
+}
+
+func TestRetryPolicy_pushes(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.HandleCall("pushing", func(req *mockserver.Request) ([]interface{}, error) {
+		call := atomic.AddInt32(&calls, 1)
+		if err := req.Push(int(call)); err != nil {
+			return nil, err
+		}
+		if call == 1 {
+			return nil, Error{Code: iproto.ER_LOADING, Msg: "loading"}
+		}
+		return []interface{}{true}, nil
+	})
+
+	conn := server.Connect(t, Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+		RetryPolicy:   testRetryPolicy,
+	})
+
+	var pushes []interface{}
+	var resp Response
+	it := conn.Do(NewCallRequest("pushing").Idempotent(true)).GetIterator().
+		WithTimeout(5 * time.Second)
+	for it.Next() {
+		if it.IsPush() {
+			data, err := it.Value().Decode()
+			require.NoError(t, err)
+			pushes = append(pushes, data...)
+		} else {
+			resp = it.Value()
+		}
+	}
+	require.NoError(t, it.Err())
+	require.NotNil(t, resp)
+	assert.Equal(t, ErrorNo, resp.Header().Error)
+	assert.Equal(t, []interface{}{int8(1), int8(2)}, pushes)
+}
+
+func TestRetryPolicy_timeout(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.HandleCall("slow", func(req *mockserver.Request) ([]interface{}, error) {
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			return nil, mockserver.ErrNoResponse
+		}
+		return []interface{}{true}, nil
+	})
+
+	conn := server.Connect(t, Opts{
+		Timeout:     100 * time.Millisecond,
+		RetryPolicy: testRetryPolicy,
+	})
+	_, err := conn.Do(NewCallRequest("slow").Idempotent(true)).Get()
+	var clientErr ClientError
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, uint32(ErrTimeouted), clientErr.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	policy := *testRetryPolicy
+	policy.ClientErrors = append([]uint32{ErrTimeouted}, DefaultRetryClientErrors...)
+	conn = server.Connect(t, Opts{
+		Timeout:     100 * time.Millisecond,
+		RetryPolicy: &policy,
+	})
+	data, err := conn.Do(NewCallRequest("slow").Idempotent(true)).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{true}, data)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_attempts(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	var calls int32
+	server.HandleCall("loading", func(req *mockserver.Request) ([]interface{}, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return nil, Error{Code: iproto.ER_LOADING, Msg: "loading"}
+		}
+		return []interface{}{true}, nil
+	})
+
+	var retries []int
+	policy := *testRetryPolicy
+	policy.OnRetry = func(req Request, attempt int, resp Response, err error) {
+		assert.NoError(t, err)
+		assert.Equal(t, iproto.ER_LOADING, resp.Header().Error)
+		retries = append(retries, attempt)
+	}
+	tracer := &mockTracer{}
+	metrics := newMockMetrics()
+	conn := server.Connect(t, Opts{
+		RetryPolicy: &policy,
+		Tracer:      tracer,
+		Metrics:     metrics,
+	})
+
+	data, err := conn.Do(NewCallRequest("loading").Idempotent(true)).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{true}, data)
+	assert.Equal(t, []int{1, 2}, retries)
+
+	traces := tracer.find(iproto.IPROTO_CALL)
+	require.Len(t, traces, 3)
+	for i, trace := range traces {
+		assert.Equal(t, 1, trace.finished)
+		require.NotNil(t, trace.resp)
+		if i < 2 {
+			assert.Equal(t, iproto.ER_LOADING, trace.resp.Header().Error)
+		} else {
+			assert.Equal(t, ErrorNo, trace.resp.Header().Error)
+		}
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	assert.Equal(t, 3, metrics.started[iproto.IPROTO_CALL])
+	assert.Equal(t, 3, metrics.done[iproto.IPROTO_CALL])
+	assert.Len(t, metrics.errors, 2)
+}
@@ -391,10 +391,14 @@ func GetSchema(doer Doer) (Schema, error) {
 	req := NewSelectRequest(vspaceSpId).
 		Index(0).
 		Limit(maxSchemas)
-	err := doer.Do(req).GetTyped(&spaces)
+	fut := doer.Do(req)
+	err := fut.GetTyped(&spaces)
 	if err != nil {
 		return Schema{}, err
 	}
+	if resp, err := fut.GetResponse(); err == nil && resp != nil {
+		schema.Version = uint(resp.Header().SchemaVersion)
+	}
 	for _, space := range spaces {
 		schema.SpacesById[space.Id] = space
 		schema.Spaces[space.Name] = space
@@ -405,10 +409,15 @@ func GetSchema(doer Doer) (Schema, error) {
 	req = NewSelectRequest(vindexSpId).
 		Index(0).
 		Limit(maxSchemas)
-	err = doer.Do(req).GetTyped(&indexes)
+	fut = doer.Do(req)
+	err = fut.GetTyped(&indexes)
 	if err != nil {
 		return Schema{}, err
 	}
+	if resp, err := fut.GetResponse(); err == nil && resp != nil &&
+		uint(resp.Header().SchemaVersion) != schema.Version {
+		return Schema{}, errors.New("concurrent schema update")
+	}
 	for _, index := range indexes {
 		spaceId := index.SpaceId
 		if _, ok := schema.SpacesById[spaceId]; ok {
@@ -537,6 +546,27 @@ func (r *loadedSchemaResolver) NamesUseSupported() bool {
 	return r.SpaceAndIndexNamesSupported
 }
 
+// versionedResolver resolves names with a loaded schema and remembers if
+// the schema has been used.
+type versionedResolver struct {
+	*loadedSchemaResolver
+	used bool
+}
+
+func (r *versionedResolver) ResolveSpace(s interface{}) (uint32, error) {
+	if _, ok := s.(string); ok {
+		r.used = true
+	}
+	return r.loadedSchemaResolver.ResolveSpace(s)
+}
+
+func (r *versionedResolver) ResolveIndex(i interface{}, spaceNo uint32) (uint32, error) {
+	if _, ok := i.(string); ok {
+		r.used = true
+	}
+	return r.loadedSchemaResolver.ResolveIndex(i, spaceNo)
+}
+
 type noSchemaResolver struct {
 	// SpaceAndIndexNamesSupported shows if a current Tarantool version supports
 	// iproto.IPROTO_FEATURE_SPACE_AND_INDEX_NAMES.
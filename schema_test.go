@@ -2,14 +2,21 @@ package tarantool_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
 	"github.com/vmihailenco/msgpack/v5"
 
 	"github.com/tarantool/go-tarantool/v2"
 	"github.com/tarantool/go-tarantool/v2/test_helpers"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
 )
 
 func TestGetSchema_ok(t *testing.T) {
@@ -160,3 +167,195 @@ func TestResolverNotCalledWithNameSupport(t *testing.T) {
 			resolver.indexResolverCalls)
 	}
 }
+
+// schemaServer is a mock server with a single space that changes its id
+// with the schema version.
+type schemaServer struct {
+	*mockserver.Server
+	mutex   sync.Mutex
+	spaceId uint32
+	reloads int
+	// fails is a count of schema reloads to fail.
+	fails int
+}
+
+// schemaServerOpts are options of a schemaServer. Names are resolved by the
+// client without IPROTO_FEATURE_SPACE_AND_INDEX_NAMES.
+var schemaServerOpts = mockserver.Opts{
+	ProtocolInfo: tarantool.ProtocolInfo{
+		Version:  3,
+		Features: []iproto.Feature{iproto.IPROTO_FEATURE_STREAMS},
+	},
+}
+
+// handleSchema sets a handler of selects from the schema spaces and the
+// space.
+func handleSchema(server *mockserver.Server) *schemaServer {
+	s := &schemaServer{Server: server, spaceId: 512}
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		switch req.SpaceId() {
+		case 281: // _vspace
+			s.reloads++
+			if s.fails > 0 {
+				s.fails--
+				return nil, tarantool.Error{Code: iproto.ER_PROC_LUA, Msg: "reload failed"}
+			}
+			return []interface{}{
+				[]interface{}{s.spaceId, 1, "test", "memtx", 0},
+			}, nil
+		case 289: // _vindex
+			return []interface{}{
+				[]interface{}{s.spaceId, 0, "pk", "tree", map[string]interface{}{},
+					[]interface{}{[]interface{}{0, "unsigned"}}},
+			}, nil
+		}
+		return []interface{}{[]interface{}{req.SpaceId()}}, nil
+	})
+	return s
+}
+
+// alter changes the space id and the schema version.
+func (s *schemaServer) alter(spaceId uint32, version uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.spaceId = spaceId
+	s.SetSchemaVersion(version)
+}
+
+func (s *schemaServer) schemaReloads() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reloads
+}
+
+func TestSchemaReload_wrongSchemaVersion(t *testing.T) {
+	server := handleSchema(mockserver.StartTest(t, schemaServerOpts))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := tarantool.Connect(ctx, tarantool.NetDialer{Address: server.Addr()},
+		tarantool.Opts{Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	req := tarantool.NewSelectRequest("test").Index("pk")
+	data, err := conn.Do(req).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{uint32(512)}}, data)
+
+	// The request is rejected with the stale schema and sent again.
+	server.alter(513, 2)
+	resp, err := conn.Do(req).GetResponse()
+	require.NoError(t, err)
+	data, err = resp.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{uint32(513)}}, data)
+	assert.Equal(t, uint64(2), resp.Header().SchemaVersion)
+	assert.Equal(t, 2, server.schemaReloads())
+
+	var versions []uint64
+	for _, r := range server.Requests() {
+		if r.Type == iproto.IPROTO_SELECT && r.SpaceId() >= 512 {
+			versions = append(versions, r.SchemaVersion)
+		}
+	}
+	assert.Equal(t, []uint64{1, 1, 2}, versions)
+
+	// Requests without names are sent without the schema version.
+	data, err = conn.Do(tarantool.NewSelectRequest(600)).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{uint32(600)}}, data)
+	requests := server.Requests()
+	assert.Equal(t, uint64(0), requests[len(requests)-1].SchemaVersion)
+}
+
+func TestSchemaReload_background(t *testing.T) {
+	server := handleSchema(mockserver.StartTest(t, schemaServerOpts))
+
+	// Internal requests of the schema reload are not intercepted.
+	var intercepted int32
+	interceptor := func(req tarantool.Request, next tarantool.Doer) *tarantool.Future {
+		atomic.AddInt32(&intercepted, 1)
+		return next.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := tarantool.Connect(ctx, tarantool.NetDialer{Address: server.Addr()},
+		tarantool.Opts{
+			Timeout:      5 * time.Second,
+			Interceptors: []tarantool.Interceptor{interceptor},
+		})
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Zero(t, atomic.LoadInt32(&intercepted))
+
+	server.alter(514, 3)
+	_, err = conn.Do(tarantool.NewPingRequest()).Get()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return server.schemaReloads() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		data, err := conn.Do(tarantool.NewSelectRequest("test")).Get()
+		require.NoError(t, err)
+		return assert.ObjectsAreEqual(
+			[]interface{}{[]interface{}{uint32(514)}}, data)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, server.schemaReloads())
+
+	// Only the ping and the selects of the test are intercepted.
+	var selects int32
+	for _, r := range server.Requests() {
+		if r.Type == iproto.IPROTO_SELECT && r.SpaceId() >= 512 {
+			selects++
+		}
+	}
+	assert.Equal(t, selects+1, atomic.LoadInt32(&intercepted))
+}
+
+func TestSchemaReload_retry(t *testing.T) {
+	server := handleSchema(mockserver.StartTest(t, schemaServerOpts))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := tarantool.Connect(ctx, tarantool.NetDialer{Address: server.Addr()},
+		tarantool.Opts{Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	server.mutex.Lock()
+	server.fails = 1
+	server.mutex.Unlock()
+	server.alter(514, 3)
+
+	// The failed reload is retried on a next response with the new version.
+	require.Eventually(t, func() bool {
+		_, err := conn.Do(tarantool.NewPingRequest()).Get()
+		require.NoError(t, err)
+		return server.schemaReloads() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		data, err := conn.Do(tarantool.NewSelectRequest("test")).Get()
+		require.NoError(t, err)
+		return assert.ObjectsAreEqual(
+			[]interface{}{[]interface{}{uint32(514)}}, data)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSchemaReload_skipSchema(t *testing.T) {
+	server := handleSchema(mockserver.StartTest(t, schemaServerOpts))
+	conn := server.Connect(t, tarantool.Opts{})
+
+	server.alter(514, 3)
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	require.NoError(t, err)
+	assert.Equal(t, 0, server.schemaReloads())
+}
@@ -0,0 +1,278 @@
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+var (
+	errInTransaction  = errors.New("transaction is already in progress")
+	errReadOnlyTx     = errors.New("read-only transactions must be linearizable")
+	errLinearizableTx = errors.New("linearizable transactions must be read-only")
+	errNotTransaction = errors.New("transaction is already finished")
+)
+
+// Conn is a connection to Tarantool. It implements driver.Conn and
+// optional interfaces of database/sql/driver.
+type Conn struct {
+	conn *tarantool.Connection
+	// stream is a stream of the current transaction or nil.
+	stream *tarantool.Stream
+}
+
+var (
+	_ driver.Conn               = (*Conn)(nil)
+	_ driver.ConnPrepareContext = (*Conn)(nil)
+	_ driver.ConnBeginTx        = (*Conn)(nil)
+	_ driver.ExecerContext      = (*Conn)(nil)
+	_ driver.QueryerContext     = (*Conn)(nil)
+	_ driver.Pinger             = (*Conn)(nil)
+	_ driver.NamedValueChecker  = (*Conn)(nil)
+	_ driver.SessionResetter    = (*Conn)(nil)
+	_ driver.Validator          = (*Conn)(nil)
+)
+
+// Connection returns the underlying connection. It could be used with
+// sql.Conn.Raw().
+func (c *Conn) Connection() *tarantool.Connection {
+	return c.conn
+}
+
+// Prepare makes Conn satisfy the driver.Conn interface.
+func (c *Conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext makes Conn satisfy the driver.ConnPrepareContext interface.
+func (c *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if c.conn.ClosedNow() {
+		return nil, driver.ErrBadConn
+	}
+
+	req := tarantool.NewPrepareRequest(query)
+	if ctx.Done() != nil {
+		req = req.Context(ctx)
+	}
+	resp, err := c.conn.Do(req).GetResponse()
+	if err != nil {
+		return nil, err
+	}
+	// NewPreparedFromResponse() hides an error type.
+	if _, err := resp.Decode(); err != nil {
+		return nil, err
+	}
+	prepared, err := tarantool.NewPreparedFromResponse(c.conn, resp)
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{conn: c, prepared: prepared}, nil
+}
+
+// Close makes Conn satisfy the driver.Conn interface.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin makes Conn satisfy the driver.Conn interface.
+func (c *Conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx makes Conn satisfy the driver.ConnBeginTx interface. It begins a
+// transaction in a new stream. Supported isolation levels are
+// sql.LevelDefault, sql.LevelReadCommitted and sql.LevelLinearizable.
+// Tarantool does not guarantee serializable transactions, so
+// sql.LevelSerializable is not supported. Tarantool allows linearizable
+// transactions only to read data, so sql.LevelLinearizable requires
+// ReadOnly options and it is the only level supported for read-only
+// transactions.
+func (c *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.conn.ClosedNow() {
+		return nil, driver.ErrBadConn
+	}
+	if c.stream != nil {
+		return nil, errInTransaction
+	}
+	isolation := sql.IsolationLevel(opts.Isolation)
+	if opts.ReadOnly && isolation != sql.LevelLinearizable {
+		return nil, errReadOnlyTx
+	}
+	if !opts.ReadOnly && isolation == sql.LevelLinearizable {
+		return nil, errLinearizableTx
+	}
+	level, err := isolationLevel(isolation)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.conn.NewStream()
+	if err != nil {
+		return nil, err
+	}
+	req := tarantool.NewBeginRequest().TxnIsolation(level)
+	if ctx.Done() != nil {
+		req = req.Context(ctx)
+	}
+	if _, err := stream.Do(req).Get(); err != nil {
+		return nil, err
+	}
+	c.stream = stream
+	return &tx{conn: c}, nil
+}
+
+// ExecContext makes Conn satisfy the driver.ExecerContext interface.
+func (c *Conn) ExecContext(ctx context.Context, query string,
+	args []driver.NamedValue) (driver.Result, error) {
+	resp, err := c.execute(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(resp)
+}
+
+// QueryContext makes Conn satisfy the driver.QueryerContext interface.
+func (c *Conn) QueryContext(ctx context.Context, query string,
+	args []driver.NamedValue) (driver.Rows, error) {
+	resp, err := c.execute(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(resp)
+}
+
+// Ping makes Conn satisfy the driver.Pinger interface.
+func (c *Conn) Ping(ctx context.Context) error {
+	if c.conn.ClosedNow() {
+		return driver.ErrBadConn
+	}
+
+	req := tarantool.NewPingRequest()
+	if ctx.Done() != nil {
+		req = req.Context(ctx)
+	}
+	_, err := c.conn.Do(req).Get()
+	return err
+}
+
+// CheckNamedValue makes Conn satisfy the driver.NamedValueChecker
+// interface. It allows to pass values of any type supported by the
+// connector, for example, decimal.Decimal or datetime.Datetime.
+func (c *Conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if valuer, ok := nv.Value.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		nv.Value = value
+	}
+	return nil
+}
+
+// ResetSession makes Conn satisfy the driver.SessionResetter interface.
+func (c *Conn) ResetSession(ctx context.Context) error {
+	if c.conn.ClosedNow() {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// IsValid makes Conn satisfy the driver.Validator interface.
+func (c *Conn) IsValid() bool {
+	return !c.conn.ClosedNow()
+}
+
+// doer returns a Doer for requests: the stream of the current transaction
+// or the connection.
+func (c *Conn) doer() tarantool.Doer {
+	if c.stream != nil {
+		return c.stream
+	}
+	return c.conn
+}
+
+func (c *Conn) execute(ctx context.Context, query string,
+	args []driver.NamedValue) (*tarantool.ExecuteResponse, error) {
+	if c.conn.ClosedNow() {
+		return nil, driver.ErrBadConn
+	}
+
+	req := tarantool.NewExecuteRequest(query).Args(binds(args))
+	if ctx.Done() != nil {
+		req = req.Context(ctx)
+	}
+	return executeResponse(c.doer().Do(req))
+}
+
+// executeResponse waits for a response of an execute request.
+func executeResponse(fut *tarantool.Future) (*tarantool.ExecuteResponse, error) {
+	resp, err := fut.GetResponse()
+	if err != nil {
+		return nil, err
+	}
+	execResp, ok := resp.(*tarantool.ExecuteResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	if _, err := execResp.Decode(); err != nil {
+		return nil, err
+	}
+	return execResp, nil
+}
+
+// binds converts arguments into SQL binds: named arguments are converted
+// into tarantool.KeyValueBind.
+func binds(args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			values[i] = tarantool.KeyValueBind{Key: arg.Name, Value: arg.Value}
+		} else {
+			values[i] = arg.Value
+		}
+	}
+	return values
+}
+
+func isolationLevel(level sql.IsolationLevel) (tarantool.TxnIsolationLevel, error) {
+	switch level {
+	case sql.LevelDefault:
+		return tarantool.DefaultIsolationLevel, nil
+	case sql.LevelReadCommitted:
+		return tarantool.ReadCommittedLevel, nil
+	case sql.LevelLinearizable:
+		return tarantool.LinearizableLevel, nil
+	}
+	return tarantool.DefaultIsolationLevel,
+		fmt.Errorf("unsupported isolation level: %s", level)
+}
+
+// tx is a transaction executed in a stream.
+type tx struct {
+	conn *Conn
+}
+
+// Commit makes tx satisfy the driver.Tx interface.
+func (t *tx) Commit() error {
+	return t.finish(tarantool.NewCommitRequest())
+}
+
+// Rollback makes tx satisfy the driver.Tx interface.
+func (t *tx) Rollback() error {
+	return t.finish(tarantool.NewRollbackRequest())
+}
+
+func (t *tx) finish(req tarantool.Request) error {
+	stream := t.conn.stream
+	if stream == nil {
+		return errNotTransaction
+	}
+	t.conn.stream = nil
+
+	_, err := stream.Do(req).Get()
+	return err
+}
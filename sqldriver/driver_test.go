@@ -0,0 +1,288 @@
+package sqldriver_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/sqldriver"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const (
+	selectQuery = "SELECT id, name FROM users WHERE id > ?"
+	insertQuery = "INSERT INTO users(name) VALUES (:name)"
+	stmtId      = 42
+)
+
+// sqlServer is a mock server that responds to a couple of SQL statements.
+type sqlServer struct {
+	*mockserver.Server
+
+	mutex sync.Mutex
+	binds [][]interface{}
+}
+
+// handleSQL sets handlers of the SQL statements for the server.
+func handleSQL(server *mockserver.Server) *sqlServer {
+	s := &sqlServer{Server: server}
+	server.Handle(iproto.IPROTO_PREPARE, func(req *mockserver.Request) ([]interface{}, error) {
+		if req.Has(iproto.IPROTO_STMT_ID) {
+			// Unprepare.
+			return nil, nil
+		}
+		if req.SQLText() != selectQuery {
+			return nil, tarantool.Error{Code: iproto.ER_SQL_PARSER_GENERIC, Msg: "syntax error"}
+		}
+		req.SetResponseField(iproto.IPROTO_STMT_ID, stmtId)
+		req.SetResponseField(iproto.IPROTO_BIND_COUNT, 1)
+		return nil, nil
+	})
+	server.Handle(iproto.IPROTO_EXECUTE, func(req *mockserver.Request) ([]interface{}, error) {
+		var binds []interface{}
+		req.Decode(iproto.IPROTO_SQL_BIND, &binds)
+		s.mutex.Lock()
+		s.binds = append(s.binds, binds)
+		s.mutex.Unlock()
+
+		var stmt uint64
+		req.Decode(iproto.IPROTO_STMT_ID, &stmt)
+
+		switch {
+		case req.SQLText() == selectQuery || stmt == stmtId:
+			req.SetResponseField(iproto.IPROTO_METADATA, []map[int]interface{}{
+				{int(iproto.IPROTO_FIELD_NAME): "ID", int(iproto.IPROTO_FIELD_TYPE): "integer"},
+				{int(iproto.IPROTO_FIELD_NAME): "NAME", int(iproto.IPROTO_FIELD_TYPE): "string"},
+			})
+			return []interface{}{
+				[]interface{}{1, "alice"},
+				[]interface{}{2, "bob"},
+			}, nil
+		case req.SQLText() == insertQuery:
+			req.SetResponseField(iproto.IPROTO_SQL_INFO, map[int]interface{}{
+				int(iproto.SQL_INFO_ROW_COUNT):         1,
+				int(iproto.SQL_INFO_AUTOINCREMENT_IDS): []uint64{3},
+			})
+			return nil, nil
+		}
+		return nil, tarantool.Error{Code: iproto.ER_SQL_PARSER_GENERIC, Msg: "syntax error"}
+	})
+	return s
+}
+
+func (s *sqlServer) lastBinds() []interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.binds[len(s.binds)-1]
+}
+
+func openDB(t *testing.T, server *sqlServer) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open(sqldriver.DriverName,
+		"tarantool://"+server.Addr()+"?skip_schema=true&timeout=5s")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+type user struct {
+	id   int64
+	name string
+}
+
+func scanUsers(t *testing.T, rows *sql.Rows) []user {
+	t.Helper()
+	defer rows.Close()
+
+	var users []user
+	for rows.Next() {
+		var u user
+		require.NoError(t, rows.Scan(&u.id, &u.name))
+		users = append(users, u)
+	}
+	require.NoError(t, rows.Err())
+	return users
+}
+
+var expectedUsers = []user{{1, "alice"}, {2, "bob"}}
+
+func TestDriver_Query(t *testing.T) {
+	server := handleSQL(mockserver.StartTest(t, mockserver.Opts{}))
+	db := openDB(t, server)
+
+	require.NoError(t, db.Ping())
+
+	rows, err := db.Query(selectQuery, 0)
+	require.NoError(t, err)
+
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "NAME"}, columns)
+	types, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "INTEGER", types[0].DatabaseTypeName())
+	assert.Equal(t, "STRING", types[1].DatabaseTypeName())
+
+	assert.Equal(t, expectedUsers, scanUsers(t, rows))
+	assert.Equal(t, []interface{}{int8(0)}, server.lastBinds())
+}
+
+func TestDriver_Exec(t *testing.T) {
+	server := handleSQL(mockserver.StartTest(t, mockserver.Opts{}))
+	db := openDB(t, server)
+
+	result, err := db.Exec(insertQuery, sql.Named("name", "carol"))
+	require.NoError(t, err)
+
+	id, err := result.LastInsertId()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+	affected, err := result.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	assert.Equal(t, []interface{}{map[string]interface{}{":name": "carol"}},
+		server.lastBinds())
+}
+
+func TestDriver_Error(t *testing.T) {
+	server := handleSQL(mockserver.StartTest(t, mockserver.Opts{}))
+	db := openDB(t, server)
+
+	_, err := db.Exec("SELECT FROM")
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_SQL_PARSER_GENERIC, tntErr.Code)
+
+	_, err = db.Prepare("SELECT FROM")
+	require.ErrorAs(t, err, &tntErr)
+}
+
+func TestDriver_Prepare(t *testing.T) {
+	server := handleSQL(mockserver.StartTest(t, mockserver.Opts{}))
+	db := openDB(t, server)
+
+	stmt, err := db.Prepare(selectQuery)
+	require.NoError(t, err)
+
+	rows, err := stmt.Query(1)
+	require.NoError(t, err)
+	assert.Equal(t, expectedUsers, scanUsers(t, rows))
+	assert.Equal(t, []interface{}{int8(1)}, server.lastBinds())
+
+	_, err = stmt.Query()
+	assert.Error(t, err)
+
+	require.NoError(t, stmt.Close())
+}
+
+func TestDriver_Tx(t *testing.T) {
+	server := handleSQL(mockserver.StartTest(t, mockserver.Opts{}))
+	db := openDB(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	require.NoError(t, err)
+	_, err = tx.Exec(insertQuery, sql.Named("name", "carol"))
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	tx, err = conn.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.Exec(insertQuery, sql.Named("name", "dave"))
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	_, err = conn.ExecContext(ctx, insertQuery, sql.Named("name", "eve"))
+	require.NoError(t, err)
+
+	_, err = conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSnapshot})
+	assert.Error(t, err)
+	_, err = conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	assert.Error(t, err)
+	_, err = conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	assert.Error(t, err)
+	_, err = conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelLinearizable})
+	assert.Error(t, err)
+
+	tx, err = conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelLinearizable,
+		ReadOnly:  true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	var streams []uint64
+	var types []iproto.Type
+	var levels []tarantool.TxnIsolationLevel
+	for _, req := range server.Requests() {
+		switch req.Type {
+		case iproto.IPROTO_BEGIN, iproto.IPROTO_EXECUTE, iproto.IPROTO_COMMIT,
+			iproto.IPROTO_ROLLBACK:
+			types = append(types, req.Type)
+			streams = append(streams, req.StreamId)
+		}
+		if req.Type == iproto.IPROTO_BEGIN {
+			var level tarantool.TxnIsolationLevel
+			req.Decode(iproto.IPROTO_TXN_ISOLATION, &level)
+			levels = append(levels, level)
+		}
+	}
+	assert.Equal(t, []iproto.Type{
+		iproto.IPROTO_BEGIN, iproto.IPROTO_EXECUTE, iproto.IPROTO_COMMIT,
+		iproto.IPROTO_BEGIN, iproto.IPROTO_EXECUTE, iproto.IPROTO_ROLLBACK,
+		iproto.IPROTO_EXECUTE, iproto.IPROTO_BEGIN, iproto.IPROTO_COMMIT,
+	}, types)
+	assert.Equal(t, []tarantool.TxnIsolationLevel{
+		tarantool.ReadCommittedLevel,
+		tarantool.DefaultIsolationLevel,
+		tarantool.LinearizableLevel,
+	}, levels)
+	require.Len(t, streams, 9)
+	assert.NotZero(t, streams[0])
+	assert.Equal(t, streams[0], streams[1])
+	assert.Equal(t, streams[0], streams[2])
+	assert.NotZero(t, streams[3])
+	assert.NotEqual(t, streams[0], streams[3])
+	assert.Equal(t, streams[3], streams[5])
+	assert.Zero(t, streams[6])
+}
+
+func TestDriver_Connector(t *testing.T) {
+	server := handleSQL(mockserver.StartTest(t, mockserver.Opts{}))
+
+	db := sql.OpenDB(sqldriver.NewConnector(
+		tarantool.NetDialer{Address: server.Addr()},
+		tarantool.Opts{SkipSchema: true, Timeout: 5 * time.Second}))
+	defer db.Close()
+
+	rows, err := db.Query(selectQuery, 0)
+	require.NoError(t, err)
+	assert.Equal(t, expectedUsers, scanUsers(t, rows))
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	err = conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*sqldriver.Conn)
+		require.True(t, ok)
+		assert.True(t, c.Connection().ConnectedNow())
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,140 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+var errNoInsertId = errors.New("no autoincrement ids in the result")
+
+// rows is a result set of an execute response.
+type rows struct {
+	columns []tarantool.ColumnMetaData
+	data    []interface{}
+	pos     int
+}
+
+var (
+	_ driver.Rows                           = (*rows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+)
+
+func newRows(resp *tarantool.ExecuteResponse) (*rows, error) {
+	data, err := resp.Decode()
+	if err != nil {
+		return nil, err
+	}
+	columns, err := resp.MetaData()
+	if err != nil {
+		return nil, err
+	}
+	return &rows{columns: columns, data: data}, nil
+}
+
+// Columns makes rows satisfy the driver.Rows interface.
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		names[i] = column.FieldName
+	}
+	return names
+}
+
+// Close makes rows satisfy the driver.Rows interface.
+func (r *rows) Close() error {
+	r.data = nil
+	return nil
+}
+
+// Next makes rows satisfy the driver.Rows interface.
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+
+	row, ok := r.data[r.pos].([]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected row type %T", r.data[r.pos])
+	}
+	if len(row) != len(dest) {
+		return fmt.Errorf("unexpected row length %d, expected %d",
+			len(row), len(dest))
+	}
+	r.pos++
+
+	for i, value := range row {
+		dest[i] = driverValue(value)
+	}
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName makes rows satisfy the
+// driver.RowsColumnTypeDatabaseTypeName interface.
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return strings.ToUpper(r.columns[index].FieldType)
+}
+
+// driverValue converts integer and float values into types expected by
+// database/sql. Other values are returned as is.
+func driverValue(value interface{}) driver.Value {
+	switch v := value.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint:
+		if uint64(v) <= math.MaxInt64 {
+			return int64(v)
+		}
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v)
+		}
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// result is a result of an execute response.
+type result struct {
+	info tarantool.SQLInfo
+}
+
+var _ driver.Result = result{}
+
+func newResult(resp *tarantool.ExecuteResponse) (result, error) {
+	info, err := resp.SQLInfo()
+	return result{info: info}, err
+}
+
+// LastInsertId makes result satisfy the driver.Result interface. It returns
+// the last autoincrement id generated by the statement.
+func (r result) LastInsertId() (int64, error) {
+	ids := r.info.InfoAutoincrementIds
+	if len(ids) == 0 {
+		return 0, errNoInsertId
+	}
+	return int64(ids[len(ids)-1]), nil
+}
+
+// RowsAffected makes result satisfy the driver.Result interface.
+func (r result) RowsAffected() (int64, error) {
+	return int64(r.info.AffectedCount), nil
+}
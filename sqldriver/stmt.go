@@ -0,0 +1,85 @@
+package sqldriver
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// stmt is a prepared statement.
+type stmt struct {
+	conn     *Conn
+	prepared *tarantool.Prepared
+}
+
+var (
+	_ driver.Stmt             = (*stmt)(nil)
+	_ driver.StmtExecContext  = (*stmt)(nil)
+	_ driver.StmtQueryContext = (*stmt)(nil)
+)
+
+// Close makes stmt satisfy the driver.Stmt interface.
+func (s *stmt) Close() error {
+	if s.conn.conn.ClosedNow() {
+		return nil
+	}
+	_, err := s.conn.conn.Do(tarantool.NewUnprepareRequest(s.prepared)).Get()
+	return err
+}
+
+// NumInput makes stmt satisfy the driver.Stmt interface.
+func (s *stmt) NumInput() int {
+	return int(s.prepared.ParamCount)
+}
+
+// Exec makes stmt satisfy the driver.Stmt interface.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+// Query makes stmt satisfy the driver.Stmt interface.
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+// ExecContext makes stmt satisfy the driver.StmtExecContext interface.
+func (s *stmt) ExecContext(ctx context.Context,
+	args []driver.NamedValue) (driver.Result, error) {
+	resp, err := s.execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(resp)
+}
+
+// QueryContext makes stmt satisfy the driver.StmtQueryContext interface.
+func (s *stmt) QueryContext(ctx context.Context,
+	args []driver.NamedValue) (driver.Rows, error) {
+	resp, err := s.execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(resp)
+}
+
+func (s *stmt) execute(ctx context.Context,
+	args []driver.NamedValue) (*tarantool.ExecuteResponse, error) {
+	if s.conn.conn.ClosedNow() {
+		return nil, driver.ErrBadConn
+	}
+
+	req := tarantool.NewExecutePreparedRequest(s.prepared).Args(binds(args))
+	if ctx.Done() != nil {
+		req = req.Context(ctx)
+	}
+	return executeResponse(s.conn.doer().Do(req))
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
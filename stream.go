@@ -23,6 +23,10 @@ const (
 	// If the BestEffortLevel (serializable) isolation level becomes unreachable,
 	// the transaction is marked as «conflicted» and can no longer be committed.
 	BestEffortLevel TxnIsolationLevel = 3
+	// The LinearizableLevel isolation level makes visible all transactions
+	// that are committed on a quorum of a replica set before the transaction
+	// start. Only read-only transactions could use it.
+	LinearizableLevel TxnIsolationLevel = 4
 )
 
 var (
@@ -197,6 +201,14 @@ func (req *RollbackRequest) Context(ctx context.Context) *RollbackRequest {
 // An error is returned if the request was formed incorrectly, or failure to
 // create the future.
 func (s *Stream) Do(req Request) *Future {
+	if len(s.Conn.opts.Interceptors) != 0 {
+		return chainDoer(s.Conn.opts.Interceptors, DoerFunc(s.do)).Do(req)
+	}
+	return s.do(req)
+}
+
+// do performs a request in the stream without interceptors.
+func (s *Stream) do(req Request) *Future {
 	if connectedReq, ok := req.(ConnectedRequest); ok {
 		if connectedReq.Conn() != s.Conn {
 			fut := NewFuture(req)
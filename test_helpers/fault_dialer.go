@@ -0,0 +1,347 @@
+package test_helpers
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+const (
+	faultPacketLengthBytes = 5
+)
+
+// ErrDialRefused is returned by FaultDialer.Dial() for refused dials.
+var ErrDialRefused = errors.New("dial refused by fault injection")
+
+// Faults controls faults injected by a FaultDialer into dials and
+// connections. All methods are safe for concurrent use, so a test could
+// inject faults while connections are in use.
+//
+// Faults injected with Drop*, Truncate* and Corrupt* methods are one-shot:
+// they are applied to the next matching operation on any connection created
+// by the dialer.
+type Faults struct {
+	mutex sync.Mutex
+	conns map[*FaultConn]struct{}
+
+	dials        uint
+	refuseDials  uint
+	dialSchedule func(attempt uint) error
+
+	readDelay  time.Duration
+	writeDelay time.Duration
+
+	dropRead     bool
+	dropWrite    bool
+	truncateRead int
+	corruptRead  bool
+	corruptLen   uint32
+}
+
+// NewFaults creates a new Faults object without any faults.
+func NewFaults() *Faults {
+	return &Faults{
+		conns: make(map[*FaultConn]struct{}),
+	}
+}
+
+// Dials returns a count of dial attempts made by the dialer.
+func (f *Faults) Dials() uint {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.dials
+}
+
+// RefuseDials makes the dialer to refuse next n dials with ErrDialRefused.
+func (f *Faults) RefuseDials(n uint) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.refuseDials = n
+}
+
+// SetDialSchedule sets a function that is called on each dial attempt with
+// a number of the attempt starting from 1. The dial is refused if the
+// function returns an error. Use nil to reset the schedule.
+func (f *Faults) SetDialSchedule(schedule func(attempt uint) error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.dialSchedule = schedule
+}
+
+// SetReadDelay sets a delay before each read from a connection.
+func (f *Faults) SetReadDelay(delay time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.readDelay = delay
+}
+
+// SetWriteDelay sets a delay before each write to a connection.
+func (f *Faults) SetWriteDelay(delay time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.writeDelay = delay
+}
+
+// DropNextRead closes a connection in the middle of the next received
+// packet. A client receives only a part of the packet and then an error.
+func (f *Faults) DropNextRead() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.dropRead = true
+}
+
+// DropNextWrite closes a connection in the middle of the next write. A
+// server receives only a part of the data.
+func (f *Faults) DropNextWrite() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.dropWrite = true
+}
+
+// TruncateNextRead cuts off n last bytes of the next received packet. The
+// length prefix of the packet stays unchanged, so a client reads the
+// beginning of the next packet as the rest of the truncated one.
+func (f *Faults) TruncateNextRead(n int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.truncateRead = n
+}
+
+// CorruptNextLength replaces the length prefix of the next received packet
+// with the length. The packet body stays unchanged.
+func (f *Faults) CorruptNextLength(length uint32) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.corruptRead = true
+	f.corruptLen = length
+}
+
+// DropConnections closes all active connections created by the dialer.
+func (f *Faults) DropConnections() {
+	f.mutex.Lock()
+	conns := make([]*FaultConn, 0, len(f.conns))
+	for conn := range f.conns {
+		conns = append(conns, conn)
+	}
+	f.mutex.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+// Reset removes all faults.
+func (f *Faults) Reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.refuseDials = 0
+	f.dialSchedule = nil
+	f.readDelay = 0
+	f.writeDelay = 0
+	f.dropRead = false
+	f.dropWrite = false
+	f.truncateRead = 0
+	f.corruptRead = false
+	f.corruptLen = 0
+}
+
+func (f *Faults) dial() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.dials++
+	if f.refuseDials > 0 {
+		f.refuseDials--
+		return ErrDialRefused
+	}
+	if f.dialSchedule != nil {
+		if err := f.dialSchedule(f.dials); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FaultDialer is a dialer-wrapper that injects network faults into dials and
+// created connections for testing purposes.
+//
+// The base dialer should return a ready-to-work connection, such as
+// tarantool.NetDialer does: FaultDialer expects that the connection
+// transfers only IPROTO packets.
+type FaultDialer struct {
+	// Dialer is a base dialer.
+	Dialer tarantool.Dialer
+	// Faults controls injected faults. It must not be nil.
+	Faults *Faults
+}
+
+// Dial makes FaultDialer satisfy the Dialer interface.
+func (d FaultDialer) Dial(ctx context.Context, opts tarantool.DialOpts) (tarantool.Conn, error) {
+	if err := d.Faults.dial(); err != nil {
+		return nil, err
+	}
+
+	conn, err := d.Dialer.Dial(ctx, opts)
+	if err != nil {
+		return conn, err
+	}
+
+	faultConn := &FaultConn{
+		Conn:   conn,
+		faults: d.Faults,
+		closed: make(chan struct{}),
+	}
+
+	d.Faults.mutex.Lock()
+	d.Faults.conns[faultConn] = struct{}{}
+	d.Faults.mutex.Unlock()
+
+	return faultConn, nil
+}
+
+// FaultConn is a connection created by FaultDialer.
+type FaultConn struct {
+	tarantool.Conn
+	faults *Faults
+
+	// pending contains received data that is not read yet.
+	pending []byte
+	// readErr is returned after the pending data has been read.
+	readErr error
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Read makes FaultConn satisfy the Conn interface.
+func (c *FaultConn) Read(p []byte) (int, error) {
+	c.faults.mutex.Lock()
+	delay := c.faults.readDelay
+	c.faults.mutex.Unlock()
+
+	if err := c.sleep(delay); err != nil {
+		return 0, err
+	}
+
+	if len(c.pending) == 0 {
+		if c.readErr != nil {
+			return 0, c.readErr
+		}
+		if err := c.readPacket(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// readPacket reads a whole packet from the base connection and applies
+// faults to it.
+func (c *FaultConn) readPacket() error {
+	var prefix [faultPacketLengthBytes]byte
+	if _, err := io.ReadFull(c.Conn, prefix[:]); err != nil {
+		return err
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.Conn, body); err != nil {
+		return err
+	}
+
+	c.faults.mutex.Lock()
+	drop := c.faults.dropRead
+	c.faults.dropRead = false
+	truncate := c.faults.truncateRead
+	c.faults.truncateRead = 0
+	corrupt := c.faults.corruptRead
+	corruptLen := c.faults.corruptLen
+	c.faults.corruptRead = false
+	c.faults.mutex.Unlock()
+
+	if corrupt {
+		binary.BigEndian.PutUint32(prefix[1:], corruptLen)
+	}
+	if truncate > 0 {
+		if truncate > len(body) {
+			truncate = len(body)
+		}
+		body = body[:len(body)-truncate]
+	}
+	if drop {
+		body = body[:len(body)/2]
+		c.readErr = io.ErrUnexpectedEOF
+		c.Close()
+	}
+
+	c.pending = append(prefix[:], body...)
+	return nil
+}
+
+// Write makes FaultConn satisfy the Conn interface.
+func (c *FaultConn) Write(p []byte) (int, error) {
+	c.faults.mutex.Lock()
+	delay := c.faults.writeDelay
+	drop := c.faults.dropWrite
+	c.faults.dropWrite = false
+	c.faults.mutex.Unlock()
+
+	if err := c.sleep(delay); err != nil {
+		return 0, err
+	}
+
+	if drop {
+		n, _ := c.Conn.Write(p[:len(p)/2])
+		c.Conn.Flush()
+		c.Close()
+		return n, io.ErrClosedPipe
+	}
+	return c.Conn.Write(p)
+}
+
+// Close makes FaultConn satisfy the Conn interface.
+func (c *FaultConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.Conn.Close()
+
+		c.faults.mutex.Lock()
+		delete(c.faults.conns, c)
+		c.faults.mutex.Unlock()
+	})
+	return err
+}
+
+// sleep waits for the delay or until the connection is closed.
+func (c *FaultConn) sleep(delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.closed:
+		return io.ErrClosedPipe
+	}
+}
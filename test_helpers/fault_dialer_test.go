@@ -0,0 +1,167 @@
+package test_helpers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const faultTimeout = 5 * time.Second
+
+func connectFaulty(t *testing.T, opts tarantool.Opts) (*mockserver.Server,
+	*tarantool.Connection, *test_helpers.Faults) {
+	t.Helper()
+
+	// Watchers are disabled to avoid unexpected events in the connection.
+	server := mockserver.StartTest(t, mockserver.Opts{
+		ProtocolInfo: tarantool.ProtocolInfo{
+			Auth:    tarantool.ChapSha1Auth,
+			Version: tarantool.ProtocolVersion(6),
+			Features: []iproto.Feature{
+				iproto.IPROTO_FEATURE_STREAMS,
+				iproto.IPROTO_FEATURE_TRANSACTIONS,
+				iproto.IPROTO_FEATURE_ERROR_EXTENSION,
+			},
+		},
+	})
+
+	faults := test_helpers.NewFaults()
+	dialer := test_helpers.FaultDialer{
+		Dialer: tarantool.NetDialer{Address: server.Addr()},
+		Faults: faults,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), faultTimeout)
+	defer cancel()
+
+	if opts.Timeout == 0 {
+		opts.Timeout = faultTimeout
+	}
+	conn, err := tarantool.Connect(ctx, dialer, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return server, conn, faults
+}
+
+func TestFaultDialer_RefuseDials(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+
+	faults := test_helpers.NewFaults()
+	dialer := test_helpers.FaultDialer{
+		Dialer: tarantool.NetDialer{Address: server.Addr()},
+		Faults: faults,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), faultTimeout)
+	defer cancel()
+
+	faults.RefuseDials(2)
+	for i := 0; i < 2; i++ {
+		_, err := dialer.Dial(ctx, tarantool.DialOpts{})
+		assert.ErrorIs(t, err, test_helpers.ErrDialRefused)
+	}
+	conn, err := dialer.Dial(ctx, tarantool.DialOpts{})
+	require.NoError(t, err)
+	conn.Close()
+
+	scheduleErr := errors.New("even attempt")
+	faults.SetDialSchedule(func(attempt uint) error {
+		if attempt%2 == 0 {
+			return scheduleErr
+		}
+		return nil
+	})
+	_, err = dialer.Dial(ctx, tarantool.DialOpts{})
+	assert.ErrorIs(t, err, scheduleErr)
+	conn, err = dialer.Dial(ctx, tarantool.DialOpts{})
+	require.NoError(t, err)
+	conn.Close()
+
+	assert.Equal(t, uint(5), faults.Dials())
+}
+
+func TestFaultDialer_Reconnect(t *testing.T) {
+	_, conn, faults := connectFaulty(t, tarantool.Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+	})
+
+	faults.RefuseDials(3)
+	faults.DropConnections()
+
+	require.Eventually(t, func() bool {
+		_, err := conn.Do(tarantool.NewPingRequest()).Get()
+		return err == nil
+	}, faultTimeout, 10*time.Millisecond)
+	assert.Equal(t, uint(5), faults.Dials())
+}
+
+func TestFaultDialer_DropNextRead(t *testing.T) {
+	_, conn, faults := connectFaulty(t, tarantool.Opts{})
+
+	faults.DropNextRead()
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	var clientErr tarantool.ClientError
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, uint32(tarantool.ErrIoError), clientErr.Code)
+}
+
+func TestFaultDialer_DropNextWrite(t *testing.T) {
+	_, conn, faults := connectFaulty(t, tarantool.Opts{})
+
+	faults.DropNextWrite()
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	require.Error(t, err)
+}
+
+func TestFaultDialer_CorruptNextLength(t *testing.T) {
+	_, conn, faults := connectFaulty(t, tarantool.Opts{})
+
+	faults.CorruptNextLength(0)
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "response should not be 0 length")
+}
+
+func TestFaultDialer_TruncateNextRead(t *testing.T) {
+	server, conn, faults := connectFaulty(t, tarantool.Opts{
+		Timeout: 200 * time.Millisecond,
+	})
+	server.HandleCall("data", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{"some data"}, nil
+	})
+
+	// The client reads bytes of a next packet as the rest of the truncated
+	// one, so it could get corrupted data instead of an error.
+	faults.TruncateNextRead(2)
+	data, err := conn.Do(tarantool.NewCallRequest("data")).Get()
+	if err == nil {
+		assert.NotEqual(t, []interface{}{"some data"}, data)
+	}
+}
+
+func TestFaultDialer_ReadDelay(t *testing.T) {
+	_, conn, faults := connectFaulty(t, tarantool.Opts{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	faults.SetReadDelay(200 * time.Millisecond)
+	_, err := conn.Do(tarantool.NewPingRequest().Context(ctx)).Get()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context is done")
+
+	faults.Reset()
+	_, err = conn.Do(tarantool.NewPingRequest()).Get()
+	require.NoError(t, err)
+}
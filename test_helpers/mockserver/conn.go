@@ -0,0 +1,469 @@
+package mockserver
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/tarantool/go-iproto"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+const (
+	uint32Code        = 0xce
+	packetLengthBytes = 5
+
+	chapSha1  = "chap-sha1"
+	papSha256 = "pap-sha256"
+)
+
+// watchState is a state of a watcher on a server side.
+type watchState struct {
+	// acked is true if the last event was acknowledged by the client.
+	acked bool
+	// pending is true if the key was updated before the acknowledgement.
+	pending bool
+}
+
+// serverConn is a client connection on the server side.
+type serverConn struct {
+	server *Server
+	net    net.Conn
+	salt   string
+
+	writeMutex sync.Mutex
+
+	mutex   sync.Mutex
+	user    string
+	watches map[string]*watchState
+}
+
+func newServerConn(server *Server, c net.Conn) (*serverConn, error) {
+	greeting, salt, err := server.greeting()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Write(greeting); err != nil {
+		return nil, err
+	}
+
+	return &serverConn{
+		server:  server,
+		net:     c,
+		salt:    salt,
+		user:    guestUser,
+		watches: make(map[string]*watchState),
+	}, nil
+}
+
+func (c *serverConn) close() error {
+	return c.net.Close()
+}
+
+// serve reads and processes requests until the connection is closed.
+func (c *serverConn) serve() {
+	defer c.close()
+
+	for {
+		req, err := c.readRequest()
+		if err != nil {
+			return
+		}
+
+		switch req.Type {
+		case iproto.IPROTO_ID:
+			c.processId(req)
+		case iproto.IPROTO_AUTH:
+			c.processAuth(req)
+		case iproto.IPROTO_WATCH:
+			c.record(req)
+			c.watch(req.EventKey())
+		case iproto.IPROTO_UNWATCH:
+			c.record(req)
+			c.unwatch(req.EventKey())
+		default:
+			c.record(req)
+			go c.process(req)
+		}
+	}
+}
+
+func (c *serverConn) record(req *Request) {
+	c.server.mutex.Lock()
+	defer c.server.mutex.Unlock()
+
+	c.server.requests = append(c.server.requests, req)
+}
+
+func (c *serverConn) readRequest() (*Request, error) {
+	var lenbuf [packetLengthBytes]byte
+	if _, err := io.ReadFull(c.net, lenbuf[:]); err != nil {
+		return nil, err
+	}
+	if lenbuf[0] != uint32Code {
+		return nil, errors.New("wrong request header")
+	}
+	packet := make([]byte, binary.BigEndian.Uint32(lenbuf[1:]))
+	if _, err := io.ReadFull(c.net, packet); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	req := &Request{
+		Body: make(map[iproto.Key]msgpack.RawMessage),
+		User: c.user,
+		conn: c,
+	}
+	c.mutex.Unlock()
+
+	d := msgpack.NewDecoder(bytes.NewReader(packet))
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return nil, err
+	}
+	for ; l > 0; l-- {
+		key, err := d.DecodeInt()
+		if err != nil {
+			return nil, err
+		}
+		switch iproto.Key(key) {
+		case iproto.IPROTO_REQUEST_TYPE:
+			var rtype int
+			if rtype, err = d.DecodeInt(); err != nil {
+				return nil, err
+			}
+			req.Type = iproto.Type(rtype)
+		case iproto.IPROTO_SYNC:
+			if req.Sync, err = d.DecodeUint64(); err != nil {
+				return nil, err
+			}
+		case iproto.IPROTO_STREAM_ID:
+			if req.StreamId, err = d.DecodeUint64(); err != nil {
+				return nil, err
+			}
+		case iproto.IPROTO_SCHEMA_VERSION:
+			if req.SchemaVersion, err = d.DecodeUint64(); err != nil {
+				return nil, err
+			}
+		default:
+			if err = d.Skip(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// A body could be omitted.
+	if l, err = d.DecodeMapLen(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return req, nil
+		}
+		return nil, err
+	}
+	for ; l > 0; l-- {
+		key, err := d.DecodeInt()
+		if err != nil {
+			return nil, err
+		}
+		raw, err := d.DecodeRaw()
+		if err != nil {
+			return nil, err
+		}
+		req.Body[iproto.Key(key)] = raw
+	}
+	return req, nil
+}
+
+// process processes a request with a user handler or a builtin one and
+// sends a response.
+func (c *serverConn) process(req *Request) {
+	c.server.mutex.Lock()
+	schemaVersion := c.server.schemaVersion
+	c.server.mutex.Unlock()
+	if req.SchemaVersion != 0 && req.SchemaVersion != schemaVersion {
+		c.writeError(req.Sync, tarantool.Error{
+			Code: iproto.ER_WRONG_SCHEMA_VERSION,
+			Msg: fmt.Sprintf("Wrong schema version, current: %d, in request: %d",
+				schemaVersion, req.SchemaVersion),
+		})
+		return
+	}
+
+	handler := c.server.handler(req)
+	if handler == nil {
+		handler = c.server.builtin
+	}
+
+	data, err := handler(req)
+	if errors.Is(err, ErrNoResponse) {
+		return
+	}
+	if err != nil {
+		c.writeError(req.Sync, err)
+		return
+	}
+
+	body := map[iproto.Key]interface{}{}
+	if req.Type != iproto.IPROTO_PING {
+		if data == nil {
+			data = []interface{}{}
+		}
+		body[iproto.IPROTO_DATA] = data
+	}
+	for key, value := range req.fields {
+		body[key] = value
+	}
+	c.writePacket(iproto.IPROTO_OK, req.Sync, body)
+}
+
+func (c *serverConn) processId(req *Request) {
+	if c.server.opts.IdUnsupported {
+		c.writeError(req.Sync, tarantool.Error{
+			Code: iproto.ER_UNKNOWN_REQUEST_TYPE,
+			Msg:  fmt.Sprintf("Unknown request type %d", req.Type),
+		})
+		return
+	}
+
+	info := c.server.opts.ProtocolInfo
+	body := map[iproto.Key]interface{}{
+		iproto.IPROTO_VERSION:  info.Version,
+		iproto.IPROTO_FEATURES: info.Features,
+	}
+	if info.Auth != tarantool.AutoAuth {
+		body[iproto.IPROTO_AUTH_TYPE] = info.Auth.String()
+	}
+	c.writePacket(iproto.IPROTO_OK, req.Sync, body)
+}
+
+func (c *serverConn) processAuth(req *Request) {
+	var user string
+	var tuple []string
+	if err := req.Decode(iproto.IPROTO_USER_NAME, &user); err != nil {
+		c.writeError(req.Sync, err)
+		return
+	}
+	if err := req.Decode(iproto.IPROTO_TUPLE, &tuple); err != nil || len(tuple) != 2 {
+		c.writeError(req.Sync, tarantool.Error{
+			Code: iproto.ER_INVALID_MSGPACK,
+			Msg:  "Invalid MsgPack - authentication request body",
+		})
+		return
+	}
+
+	if err := c.server.checkCredentials(user, tuple[0], tuple[1], c.salt); err != nil {
+		c.writeError(req.Sync, err)
+		return
+	}
+
+	c.mutex.Lock()
+	c.user = user
+	c.mutex.Unlock()
+
+	c.writePacket(iproto.IPROTO_OK, req.Sync, map[iproto.Key]interface{}{})
+}
+
+// checkCredentials checks that the user could be authenticated with the
+// method and the scramble.
+func (s *Server) checkCredentials(user, method, scramble, salt string) error {
+	if s.opts.Users == nil {
+		return nil
+	}
+
+	credsErr := tarantool.Error{
+		Code: iproto.ER_CREDS_MISMATCH,
+		Msg:  "User not found or supplied credentials are invalid",
+	}
+	pass, ok := s.opts.Users[user]
+	if !ok {
+		return credsErr
+	}
+
+	switch method {
+	case chapSha1:
+		expected, err := chapSha1Scramble(salt, pass)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(expected, []byte(scramble)) {
+			return credsErr
+		}
+	case papSha256:
+		if pass != scramble {
+			return credsErr
+		}
+	default:
+		return tarantool.Error{
+			Code: iproto.ER_UNKNOWN_AUTH_METHOD,
+			Msg:  fmt.Sprintf("Unknown authentication method '%s'", method),
+		}
+	}
+	return nil
+}
+
+// chapSha1Scramble returns an expected chap-sha1 scramble for the salt and
+// the password.
+func chapSha1Scramble(encodedSalt, pass string) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return nil, err
+	}
+	step1 := sha1.Sum([]byte(pass))
+	step2 := sha1.Sum(step1[:])
+	hash := sha1.New()
+	hash.Write(salt[:sha1.Size])
+	hash.Write(step2[:])
+	step3 := hash.Sum(nil)
+
+	scramble := make([]byte, sha1.Size)
+	for i := range scramble {
+		scramble[i] = step1[i] ^ step3[i]
+	}
+	return scramble, nil
+}
+
+// watch subscribes the connection to the key or acknowledges the last event.
+func (c *serverConn) watch(key string) {
+	c.mutex.Lock()
+	state, ok := c.watches[key]
+	send := false
+	if !ok {
+		c.watches[key] = &watchState{}
+		send = true
+	} else if state.pending {
+		state.pending = false
+		send = true
+	} else {
+		state.acked = true
+	}
+	c.mutex.Unlock()
+
+	if send {
+		c.server.mutex.Lock()
+		value, ok := c.server.events[key]
+		c.server.mutex.Unlock()
+		c.writeEvent(key, value, ok)
+	}
+}
+
+// unwatch unsubscribes the connection from the key.
+func (c *serverConn) unwatch(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.watches, key)
+}
+
+// notify sends an event to the client if it is subscribed to the key and
+// has acknowledged the last event.
+func (c *serverConn) notify(key string, value interface{}) {
+	c.mutex.Lock()
+	state, ok := c.watches[key]
+	send := false
+	if ok {
+		if state.acked {
+			state.acked = false
+			send = true
+		} else {
+			state.pending = true
+		}
+	}
+	c.mutex.Unlock()
+
+	if send {
+		c.writeEvent(key, value, true)
+	}
+}
+
+func (c *serverConn) writeEvent(key string, value interface{}, exist bool) error {
+	body := map[iproto.Key]interface{}{
+		iproto.IPROTO_EVENT_KEY: key,
+	}
+	if exist {
+		body[iproto.IPROTO_EVENT_DATA] = value
+	}
+	return c.writePacket(iproto.IPROTO_EVENT, 0, body)
+}
+
+func (c *serverConn) writeError(sync uint64, err error) error {
+	var tntErr tarantool.Error
+	if !errors.As(err, &tntErr) {
+		tntErr = tarantool.Error{
+			Code: iproto.ER_PROC_LUA,
+			Msg:  err.Error(),
+		}
+	}
+
+	body := map[iproto.Key]interface{}{
+		iproto.IPROTO_ERROR_24: tntErr.Msg,
+	}
+	if tntErr.ExtendedInfo != nil {
+		stack, err := tntErr.ExtendedInfo.MarshalMsgpack()
+		if err != nil {
+			return err
+		}
+		body[iproto.IPROTO_ERROR] = msgpack.RawMessage(stack)
+	}
+	code := iproto.Type(iproto.IPROTO_TYPE_ERROR) | iproto.Type(tntErr.Code)
+	return c.writePacket(code, sync, body)
+}
+
+// writePacket encodes and writes a packet with the header and the body.
+func (c *serverConn) writePacket(code iproto.Type, sync uint64,
+	body map[iproto.Key]interface{}) error {
+	c.server.mutex.Lock()
+	schemaVersion := c.server.schemaVersion
+	c.server.mutex.Unlock()
+
+	var buf bytes.Buffer
+	buf.Write([]byte{uint32Code, 0, 0, 0, 0})
+
+	enc := msgpack.NewEncoder(&buf)
+	if err := enc.EncodeMapLen(3); err != nil {
+		return err
+	}
+	header := []struct {
+		key   iproto.Key
+		value uint64
+	}{
+		{iproto.IPROTO_REQUEST_TYPE, uint64(code)},
+		{iproto.IPROTO_SYNC, sync},
+		{iproto.IPROTO_SCHEMA_VERSION, schemaVersion},
+	}
+	for _, field := range header {
+		if err := enc.EncodeUint(uint64(field.key)); err != nil {
+			return err
+		}
+		if err := enc.EncodeUint(field.value); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeMapLen(len(body)); err != nil {
+		return err
+	}
+	for key, value := range body {
+		if err := enc.EncodeUint(uint64(key)); err != nil {
+			return err
+		}
+		if err := enc.Encode(value); err != nil {
+			return err
+		}
+	}
+
+	packet := buf.Bytes()
+	binary.BigEndian.PutUint32(packet[1:], uint32(len(packet)-packetLengthBytes))
+
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	_, err := c.net.Write(packet)
+	return err
+}
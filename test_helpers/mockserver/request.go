@@ -0,0 +1,157 @@
+package mockserver
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tarantool/go-iproto"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Request is a request received by the Server from a client.
+type Request struct {
+	// Type is the IPROTO type of the request.
+	Type iproto.Type
+	// Sync is the request ID (IPROTO_SYNC) set by the client.
+	Sync uint64
+	// StreamId is the stream ID (IPROTO_STREAM_ID) or 0 if the request does
+	// not belong to a stream.
+	StreamId uint64
+	// SchemaVersion is the schema version (IPROTO_SCHEMA_VERSION) set by
+	// the client or 0 if it is not set. The server rejects a request with
+	// ER_WRONG_SCHEMA_VERSION if the version differs from the current one.
+	SchemaVersion uint64
+	// Body contains raw msgpack values of the request body by keys.
+	Body map[iproto.Key]msgpack.RawMessage
+	// User is a name of the user authenticated on the connection. It is
+	// "guest" if the client has not authenticated.
+	User string
+
+	conn   *serverConn
+	fields map[iproto.Key]interface{}
+}
+
+// Decode decodes a value of the body key into v. It returns an error if
+// there is no such key in the request body.
+func (req *Request) Decode(key iproto.Key, v interface{}) error {
+	raw, ok := req.Body[key]
+	if !ok {
+		return fmt.Errorf("request has no %s key", key)
+	}
+	return msgpack.NewDecoder(bytes.NewReader(raw)).Decode(v)
+}
+
+// Has returns true if the request body contains the key.
+func (req *Request) Has(key iproto.Key) bool {
+	_, ok := req.Body[key]
+	return ok
+}
+
+func (req *Request) decodeString(key iproto.Key) string {
+	var str string
+	req.Decode(key, &str)
+	return str
+}
+
+func (req *Request) decodeUint32(key iproto.Key) uint32 {
+	var num uint32
+	req.Decode(key, &num)
+	return num
+}
+
+func (req *Request) decodeArray(key iproto.Key) []interface{} {
+	var arr []interface{}
+	req.Decode(key, &arr)
+	return arr
+}
+
+// FunctionName returns a function name of a call request.
+func (req *Request) FunctionName() string {
+	return req.decodeString(iproto.IPROTO_FUNCTION_NAME)
+}
+
+// Expression returns an expression of an eval request.
+func (req *Request) Expression() string {
+	return req.decodeString(iproto.IPROTO_EXPR)
+}
+
+// SQLText returns a SQL statement of an execute or a prepare request.
+func (req *Request) SQLText() string {
+	return req.decodeString(iproto.IPROTO_SQL_TEXT)
+}
+
+// SpaceId returns a space ID of a space request.
+func (req *Request) SpaceId() uint32 {
+	return req.decodeUint32(iproto.IPROTO_SPACE_ID)
+}
+
+// SpaceName returns a space name of a space request.
+func (req *Request) SpaceName() string {
+	return req.decodeString(iproto.IPROTO_SPACE_NAME)
+}
+
+// IndexId returns an index ID of a space request.
+func (req *Request) IndexId() uint32 {
+	return req.decodeUint32(iproto.IPROTO_INDEX_ID)
+}
+
+// IndexName returns an index name of a space request.
+func (req *Request) IndexName() string {
+	return req.decodeString(iproto.IPROTO_INDEX_NAME)
+}
+
+// Key returns a key of a select, a delete or an update request.
+func (req *Request) Key() []interface{} {
+	return req.decodeArray(iproto.IPROTO_KEY)
+}
+
+// Tuple returns a tuple of an insert, a replace or an upsert request, or
+// arguments of a call or an eval request.
+func (req *Request) Tuple() []interface{} {
+	return req.decodeArray(iproto.IPROTO_TUPLE)
+}
+
+// Limit returns a limit of a select request.
+func (req *Request) Limit() uint32 {
+	return req.decodeUint32(iproto.IPROTO_LIMIT)
+}
+
+// Offset returns an offset of a select request.
+func (req *Request) Offset() uint32 {
+	return req.decodeUint32(iproto.IPROTO_OFFSET)
+}
+
+// Iterator returns an iterator type of a select request.
+func (req *Request) Iterator() uint32 {
+	return req.decodeUint32(iproto.IPROTO_ITERATOR)
+}
+
+// EventKey returns a key of a watch, an unwatch or a watch once request.
+func (req *Request) EventKey() string {
+	return req.decodeString(iproto.IPROTO_EVENT_KEY)
+}
+
+// Push sends a push message (IPROTO_CHUNK) with the data for the request to
+// the client.
+func (req *Request) Push(data interface{}) error {
+	body := map[iproto.Key]interface{}{
+		iproto.IPROTO_DATA: []interface{}{data},
+	}
+	return req.conn.writePacket(iproto.IPROTO_CHUNK, req.Sync, body)
+}
+
+// SetResponseField sets a field of a successful response body in addition to
+// IPROTO_DATA, for example, IPROTO_SQL_INFO or IPROTO_METADATA. It should be
+// called from a handler.
+func (req *Request) SetResponseField(key iproto.Key, value interface{}) {
+	if req.fields == nil {
+		req.fields = map[iproto.Key]interface{}{}
+	}
+	req.fields[key] = value
+}
+
+// Close closes the client connection the request came from. It could be
+// used to simulate a network failure in the middle of request processing.
+func (req *Request) Close() error {
+	return req.conn.close()
+}
@@ -0,0 +1,400 @@
+// Package mockserver implements an in-process Tarantool server for testing
+// purposes.
+//
+// The server listens on a local socket and speaks the IPROTO protocol: it
+// sends a greeting, handles IPROTO_ID and IPROTO_AUTH requests, watchers and
+// pings by itself. All other requests are passed to handlers registered by
+// a test per request type, per function name for calls or per expression for
+// evals. It allows to run tarantool.Connect(), pool.Connect() and wrappers on
+// top of them end to end without a tarantool binary installed.
+package mockserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+const (
+	defaultListen  = "127.0.0.1:0"
+	defaultVersion = "Tarantool 3.0.0 (Binary) 00000000-0000-0000-0000-000000000000"
+	greetingSize   = 128
+	guestUser      = "guest"
+
+	boxStatusKey = "box.status"
+	vspaceSpId   = 281
+	vindexSpId   = 289
+)
+
+// ErrNoResponse could be returned by a Handler to not send any response for
+// a request. It could be used to simulate a lost response or a hung
+// request.
+var ErrNoResponse = errors.New("mockserver: no response")
+
+// Handler processes a request. The returned data is sent to the client as
+// IPROTO_DATA of the response.
+//
+// The returned error is sent to the client as an error response. It is sent
+// as is if it is a tarantool.Error, otherwise as an ER_PROC_LUA error with
+// a message of the error. See also ErrNoResponse.
+type Handler func(req *Request) ([]interface{}, error)
+
+// Opts configures a Server.
+type Opts struct {
+	// Listen is an address to listen. A path to a Unix socket should start
+	// with '/' or '.'. By default, the server listens on a random TCP port
+	// of 127.0.0.1.
+	Listen string
+	// Version is a version string sent in the greeting message. By default,
+	// it is a string of Tarantool 3.0.0.
+	Version string
+	// ProtocolInfo is sent as a response to IPROTO_ID request. By default,
+	// it contains all the features supported by the connector and the
+	// chap-sha1 authentication method.
+	ProtocolInfo tarantool.ProtocolInfo
+	// IdUnsupported makes the server to respond with ER_UNKNOWN_REQUEST_TYPE
+	// to IPROTO_ID requests as Tarantool < 2.10 does.
+	IdUnsupported bool
+	// Users is a map of user names to passwords. If it is nil, any user
+	// with any password is allowed to authenticate.
+	Users map[string]string
+	// ReadOnly is an initial read-only state of the instance. It is reported
+	// by "box.status" event and "box.info" call.
+	ReadOnly bool
+}
+
+// Server is an in-process server that speaks the IPROTO protocol.
+type Server struct {
+	opts      Opts
+	listener  net.Listener
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+
+	mutex         sync.Mutex
+	conns         map[*serverConn]struct{}
+	types         map[iproto.Type]Handler
+	calls         map[string]Handler
+	evals         map[string]Handler
+	events        map[string]interface{}
+	requests      []*Request
+	readOnly      bool
+	schemaVersion uint64
+}
+
+// Start creates a new Server and starts to accept connections.
+func Start(opts Opts) (*Server, error) {
+	if opts.Listen == "" {
+		opts.Listen = defaultListen
+	}
+	if opts.Version == "" {
+		opts.Version = defaultVersion
+	}
+	if opts.ProtocolInfo.Version == 0 && opts.ProtocolInfo.Features == nil {
+		opts.ProtocolInfo = tarantool.ProtocolInfo{
+			Auth:    tarantool.ChapSha1Auth,
+			Version: tarantool.ProtocolVersion(6),
+			Features: []iproto.Feature{
+				iproto.IPROTO_FEATURE_STREAMS,
+				iproto.IPROTO_FEATURE_TRANSACTIONS,
+				iproto.IPROTO_FEATURE_ERROR_EXTENSION,
+				iproto.IPROTO_FEATURE_WATCHERS,
+				iproto.IPROTO_FEATURE_PAGINATION,
+				iproto.IPROTO_FEATURE_SPACE_AND_INDEX_NAMES,
+				iproto.IPROTO_FEATURE_WATCH_ONCE,
+			},
+		}
+	}
+
+	network := "tcp"
+	if strings.HasPrefix(opts.Listen, "/") || strings.HasPrefix(opts.Listen, ".") {
+		network = "unix"
+	}
+	listener, err := net.Listen(network, opts.Listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s := &Server{
+		opts:          opts,
+		listener:      listener,
+		done:          make(chan struct{}),
+		conns:         make(map[*serverConn]struct{}),
+		types:         make(map[iproto.Type]Handler),
+		calls:         make(map[string]Handler),
+		evals:         make(map[string]Handler),
+		events:        make(map[string]interface{}),
+		readOnly:      opts.ReadOnly,
+		schemaVersion: 1,
+	}
+	s.events[boxStatusKey] = s.boxStatus()
+
+	s.wg.Add(1)
+	go s.accept()
+
+	return s, nil
+}
+
+// Addr returns an address the server listens on. It could be used as
+// tarantool.NetDialer.Address.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Handle registers a handler for all requests of the type. Handlers for
+// calls and evals registered with HandleCall() and HandleEval() have
+// priority over it.
+func (s *Server) Handle(rtype iproto.Type, handler Handler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.types[rtype] = handler
+}
+
+// HandleCall registers a handler for call requests of the function.
+func (s *Server) HandleCall(function string, handler Handler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls[function] = handler
+}
+
+// HandleEval registers a handler for eval requests of the expression.
+func (s *Server) HandleEval(expr string, handler Handler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.evals[expr] = handler
+}
+
+// Broadcast updates a value of the key and notifies all watchers of the key
+// as box.broadcast() does.
+func (s *Server) Broadcast(key string, value interface{}) {
+	s.mutex.Lock()
+	s.events[key] = value
+	conns := s.connsLocked()
+	s.mutex.Unlock()
+
+	for _, conn := range conns {
+		conn.notify(key, value)
+	}
+}
+
+// SetReadOnly changes the read-only state of the instance. It broadcasts a
+// new "box.status" value.
+func (s *Server) SetReadOnly(ro bool) {
+	s.mutex.Lock()
+	s.readOnly = ro
+	status := s.boxStatus()
+	s.mutex.Unlock()
+
+	s.Broadcast(boxStatusKey, status)
+}
+
+// SetSchemaVersion sets a schema version sent in headers of responses
+// (IPROTO_SCHEMA_VERSION).
+func (s *Server) SetSchemaVersion(version uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.schemaVersion = version
+}
+
+// Requests returns all requests processed by the server except IPROTO_ID
+// and IPROTO_AUTH.
+func (s *Server) Requests() []*Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requests := make([]*Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// DropConnections closes all active client connections. The server
+// continues to accept new connections.
+func (s *Server) DropConnections() {
+	s.mutex.Lock()
+	conns := s.connsLocked()
+	s.mutex.Unlock()
+
+	for _, conn := range conns {
+		conn.close()
+	}
+}
+
+// Close stops the server and closes all client connections.
+func (s *Server) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		err = s.listener.Close()
+		s.DropConnections()
+		s.wg.Wait()
+	})
+	return err
+}
+
+func (s *Server) connsLocked() []*serverConn {
+	conns := make([]*serverConn, 0, len(s.conns))
+	for conn := range s.conns {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+func (s *Server) boxStatus() map[string]interface{} {
+	return map[string]interface{}{
+		"is_ro":     s.readOnly,
+		"is_ro_cfg": s.readOnly,
+		"status":    "running",
+	}
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		conn, err := newServerConn(s, c)
+		if err != nil {
+			c.Close()
+			continue
+		}
+
+		s.mutex.Lock()
+		select {
+		case <-s.done:
+			s.mutex.Unlock()
+			c.Close()
+			return
+		default:
+		}
+		s.conns[conn] = struct{}{}
+		s.mutex.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			conn.serve()
+
+			s.mutex.Lock()
+			delete(s.conns, conn)
+			s.mutex.Unlock()
+		}()
+	}
+}
+
+// greeting returns a greeting message for a new connection.
+func (s *Server) greeting() ([]byte, string, error) {
+	var rawSalt [32]byte
+	if _, err := rand.Read(rawSalt[:]); err != nil {
+		return nil, "", err
+	}
+	salt := base64.StdEncoding.EncodeToString(rawSalt[:])
+
+	greeting := make([]byte, greetingSize)
+	for i := range greeting {
+		greeting[i] = ' '
+	}
+	copy(greeting, s.opts.Version)
+	greeting[greetingSize/2-1] = '\n'
+	copy(greeting[greetingSize/2:], salt)
+	greeting[greetingSize-1] = '\n'
+
+	return greeting, salt, nil
+}
+
+// handler returns a user handler for the request or nil.
+func (s *Server) handler(req *Request) Handler {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch req.Type {
+	case iproto.IPROTO_CALL, iproto.IPROTO_CALL_16:
+		if handler, ok := s.calls[req.FunctionName()]; ok {
+			return handler
+		}
+	case iproto.IPROTO_EVAL:
+		if handler, ok := s.evals[req.Expression()]; ok {
+			return handler
+		}
+	}
+	return s.types[req.Type]
+}
+
+// builtin processes requests that are not handled by a user handler.
+func (s *Server) builtin(req *Request) ([]interface{}, error) {
+	switch req.Type {
+	case iproto.IPROTO_PING, iproto.IPROTO_BEGIN, iproto.IPROTO_COMMIT,
+		iproto.IPROTO_ROLLBACK:
+		return nil, nil
+	case iproto.IPROTO_WATCH_ONCE:
+		s.mutex.Lock()
+		value, ok := s.events[req.EventKey()]
+		s.mutex.Unlock()
+		if !ok {
+			return []interface{}{}, nil
+		}
+		return []interface{}{value}, nil
+	case iproto.IPROTO_CALL, iproto.IPROTO_CALL_16:
+		function := req.FunctionName()
+		if function == "box.info" {
+			s.mutex.Lock()
+			info := map[string]interface{}{
+				"ro":     s.readOnly,
+				"status": "running",
+			}
+			s.mutex.Unlock()
+			return []interface{}{info}, nil
+		}
+		return nil, tarantool.Error{
+			Code: iproto.ER_NO_SUCH_PROC,
+			Msg:  fmt.Sprintf("Procedure '%s' is not defined", function),
+		}
+	case iproto.IPROTO_EVAL:
+		return nil, tarantool.Error{
+			Code: iproto.ER_PROC_LUA,
+			Msg:  fmt.Sprintf("unexpected expression: %s", req.Expression()),
+		}
+	case iproto.IPROTO_SELECT:
+		if !req.Has(iproto.IPROTO_SPACE_NAME) {
+			switch req.SpaceId() {
+			case vspaceSpId, vindexSpId:
+				return []interface{}{}, nil
+			}
+		}
+		return nil, noSuchSpace(req)
+	case iproto.IPROTO_INSERT, iproto.IPROTO_REPLACE, iproto.IPROTO_UPDATE,
+		iproto.IPROTO_UPSERT, iproto.IPROTO_DELETE:
+		return nil, noSuchSpace(req)
+	}
+	return nil, tarantool.Error{
+		Code: iproto.ER_UNKNOWN_REQUEST_TYPE,
+		Msg:  fmt.Sprintf("Unknown request type %d", req.Type),
+	}
+}
+
+func noSuchSpace(req *Request) error {
+	space := fmt.Sprint(req.SpaceId())
+	if req.Has(iproto.IPROTO_SPACE_NAME) {
+		space = req.SpaceName()
+	}
+	return tarantool.Error{
+		Code: iproto.ER_NO_SUCH_SPACE,
+		Msg:  fmt.Sprintf("Space '%s' does not exist", space),
+	}
+}
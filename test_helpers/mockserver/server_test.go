@@ -0,0 +1,285 @@
+package mockserver_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+const timeout = 5 * time.Second
+
+func TestServer_Connect(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	conn := server.Connect(t, tarantool.Opts{})
+
+	_, err := conn.Do(tarantool.NewPingRequest()).Get()
+	require.NoError(t, err)
+
+	info := conn.ProtocolInfo()
+	assert.Equal(t, tarantool.ProtocolVersion(6), info.Version)
+	assert.Contains(t, info.Features, iproto.IPROTO_FEATURE_WATCHERS)
+	assert.Contains(t, conn.Greeting.Version, "Tarantool 3.0.0 (Binary)")
+}
+
+func TestServer_Connect_IdUnsupported(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{IdUnsupported: true})
+	conn := server.Connect(t, tarantool.Opts{})
+
+	assert.Equal(t, tarantool.ProtocolInfo{}, conn.ProtocolInfo())
+}
+
+func TestServer_Auth(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{
+		Users: map[string]string{"test": "secret"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	conn, err := tarantool.Connect(ctx, tarantool.NetDialer{
+		Address:  server.Addr(),
+		User:     "test",
+		Password: "secret",
+	}, tarantool.Opts{})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	server.Handle(iproto.IPROTO_CALL, func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{req.User}, nil
+	})
+	data, err := conn.Do(tarantool.NewCallRequest("whoami")).Get()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"test"}, data)
+
+	_, err = tarantool.Connect(ctx, tarantool.NetDialer{
+		Address:  server.Addr(),
+		User:     "test",
+		Password: "wrong",
+	}, tarantool.Opts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "supplied credentials are invalid")
+}
+
+func TestServer_HandleCall(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("sum", func(req *mockserver.Request) ([]interface{}, error) {
+		var args []int
+		if err := req.Decode(iproto.IPROTO_TUPLE, &args); err != nil {
+			return nil, err
+		}
+		sum := 0
+		for _, arg := range args {
+			sum += arg
+		}
+		return []interface{}{sum}, nil
+	})
+	server.HandleEval("return 1", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{1}, nil
+	})
+
+	conn := server.Connect(t, tarantool.Opts{})
+
+	var sum []int
+	err := conn.Do(tarantool.NewCallRequest("sum").Args([]interface{}{1, 2, 3})).
+		GetTyped(&sum)
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, sum)
+
+	var one []int
+	err = conn.Do(tarantool.NewEvalRequest("return 1")).GetTyped(&one)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, one)
+
+	_, err = conn.Do(tarantool.NewCallRequest("unknown")).Get()
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_NO_SUCH_PROC, tntErr.Code)
+
+	requests := server.Requests()
+	require.NotEmpty(t, requests)
+	last := requests[len(requests)-1]
+	assert.Equal(t, iproto.IPROTO_CALL, last.Type)
+	assert.Equal(t, "unknown", last.FunctionName())
+}
+
+func TestServer_HandleError(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	boxErr := &tarantool.BoxError{
+		Type: "ClientError",
+		Msg:  "Duplicate key exists",
+		Code: uint64(iproto.ER_TUPLE_FOUND),
+	}
+	server.Handle(iproto.IPROTO_INSERT, func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, tarantool.Error{
+			Code:         iproto.ER_TUPLE_FOUND,
+			Msg:          boxErr.Msg,
+			ExtendedInfo: boxErr,
+		}
+	})
+
+	conn := server.Connect(t, tarantool.Opts{})
+
+	_, err := conn.Do(tarantool.NewInsertRequest(512).
+		Tuple([]interface{}{1})).Get()
+	var tntErr tarantool.Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_TUPLE_FOUND, tntErr.Code)
+	assert.Equal(t, boxErr, tntErr.ExtendedInfo)
+}
+
+func TestServer_Push(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("push", func(req *mockserver.Request) ([]interface{}, error) {
+		for i := 1; i <= 3; i++ {
+			if err := req.Push(i); err != nil {
+				return nil, err
+			}
+		}
+		return []interface{}{4}, nil
+	})
+
+	conn := server.Connect(t, tarantool.Opts{})
+
+	var pushes []interface{}
+	var resp tarantool.Response
+	it := conn.Do(tarantool.NewCallRequest("push")).GetIterator().
+		WithTimeout(timeout)
+	for it.Next() {
+		if it.IsPush() {
+			data, err := it.Value().Decode()
+			require.NoError(t, err)
+			pushes = append(pushes, data...)
+		} else {
+			resp = it.Value()
+		}
+	}
+	require.NoError(t, it.Err())
+	require.NotNil(t, resp)
+	assert.Equal(t, []interface{}{int8(1), int8(2), int8(3)}, pushes)
+}
+
+func TestServer_NoResponse(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("hang", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, mockserver.ErrNoResponse
+	})
+
+	conn := server.Connect(t, tarantool.Opts{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := conn.Do(tarantool.NewCallRequest("hang").Context(ctx)).Get()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context is done")
+}
+
+func TestServer_Broadcast(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	conn := server.Connect(t, tarantool.Opts{})
+
+	events := make(chan tarantool.WatchEvent, 10)
+	watcher, err := conn.NewWatcher("key", func(event tarantool.WatchEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	defer watcher.Unregister()
+
+	waitEvent := func() tarantool.WatchEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(timeout):
+			t.Fatalf("no event")
+		}
+		return tarantool.WatchEvent{}
+	}
+
+	assert.Nil(t, waitEvent().Value)
+
+	server.Broadcast("key", "value")
+	assert.Equal(t, "value", waitEvent().Value)
+
+	server.Broadcast("key", "new value")
+	assert.Equal(t, "new value", waitEvent().Value)
+}
+
+func TestServer_Reconnect(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	conn := server.Connect(t, tarantool.Opts{
+		Reconnect:     10 * time.Millisecond,
+		MaxReconnects: 100,
+	})
+
+	server.DropConnections()
+
+	require.Eventually(t, func() bool {
+		_, err := conn.Do(tarantool.NewPingRequest()).Get()
+		return err == nil
+	}, timeout, 10*time.Millisecond)
+}
+
+func TestServer_Pool(t *testing.T) {
+	rw := mockserver.StartTest(t, mockserver.Opts{})
+	ro := mockserver.StartTest(t, mockserver.Opts{ReadOnly: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	instances := []pool.Instance{
+		{
+			Name:   "rw",
+			Dialer: tarantool.NetDialer{Address: rw.Addr()},
+			Opts:   tarantool.Opts{Timeout: timeout},
+		},
+		{
+			Name:   "ro",
+			Dialer: tarantool.NetDialer{Address: ro.Addr()},
+			Opts:   tarantool.Opts{Timeout: timeout},
+		},
+	}
+	connPool, err := pool.ConnectWithOpts(ctx, instances, pool.Opts{
+		CheckTimeout: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer connPool.Close()
+
+	info := connPool.GetInfo()
+	assert.Equal(t, pool.MasterRole, info["rw"].ConnRole)
+	assert.Equal(t, pool.ReplicaRole, info["ro"].ConnRole)
+
+	rw.SetReadOnly(true)
+	ro.SetReadOnly(false)
+
+	require.Eventually(t, func() bool {
+		info := connPool.GetInfo()
+		return info["rw"].ConnRole == pool.ReplicaRole &&
+			info["ro"].ConnRole == pool.MasterRole
+	}, timeout, 10*time.Millisecond)
+
+	_, err = connPool.Do(tarantool.NewPingRequest(), pool.RW).Get()
+	require.NoError(t, err)
+}
+
+func TestServer_CloseConcurrent(t *testing.T) {
+	server, err := mockserver.Start(mockserver.Opts{})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server.Close()
+		}()
+	}
+	wg.Wait()
+}
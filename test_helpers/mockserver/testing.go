@@ -0,0 +1,60 @@
+package mockserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tarantool/go-tarantool/v2"
+)
+
+// testTimeout is a timeout of connections created by Connect().
+const testTimeout = 5 * time.Second
+
+// StartTest starts a Server and closes it at the end of the test.
+func StartTest(tb testing.TB, opts Opts) *Server {
+	tb.Helper()
+
+	server, err := Start(opts)
+	if err != nil {
+		tb.Fatalf("Failed to start a mock server: %s", err)
+	}
+	tb.Cleanup(func() {
+		server.Close()
+	})
+	return server
+}
+
+// Connect connects to the server with tarantool.NetDialer and closes the
+// connection at the end of the test.
+//
+// The connection does not load a schema: Opts.SkipSchema is always set.
+// Opts.Timeout is 5 seconds if it is not set.
+func (s *Server) Connect(tb testing.TB, opts tarantool.Opts) *tarantool.Connection {
+	tb.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	opts.SkipSchema = true
+	if opts.Timeout == 0 {
+		opts.Timeout = testTimeout
+	}
+	conn, err := tarantool.Connect(ctx, tarantool.NetDialer{Address: s.Addr()}, opts)
+	if err != nil {
+		tb.Fatalf("Failed to connect to a mock server: %s", err)
+	}
+	tb.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+// Connect starts a Server with default options and connects to it, see
+// Server.Connect(). Both are closed at the end of the test.
+func Connect(tb testing.TB, opts tarantool.Opts) (*Server, *tarantool.Connection) {
+	tb.Helper()
+
+	server := StartTest(tb, Opts{})
+	return server, server.Connect(tb, opts)
+}
@@ -0,0 +1,154 @@
+package test_helpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const certsValidity = 24 * time.Hour
+
+// Certs contains paths to files generated by GenerateCerts.
+type Certs struct {
+	// CaFile is a path to a certificate of the certificate authority.
+	CaFile string
+	// ServerCertFile is a path to a server certificate signed by the CA.
+	ServerCertFile string
+	// ServerKeyFile is a path to a private key of the server.
+	ServerKeyFile string
+	// ClientCertFile is a path to a client certificate signed by the CA.
+	ClientCertFile string
+	// ClientKeyFile is a path to a private key of the client.
+	ClientKeyFile string
+}
+
+// GenerateCerts generates a self-signed certificate authority and server and
+// client certificates signed by it into the directory. The server
+// certificate is valid for "localhost", "127.0.0.1" and "::1".
+func GenerateCerts(dir string) (Certs, error) {
+	certs := Certs{
+		CaFile:         filepath.Join(dir, "ca.crt"),
+		ServerCertFile: filepath.Join(dir, "server.crt"),
+		ServerKeyFile:  filepath.Join(dir, "server.key"),
+		ClientCertFile: filepath.Join(dir, "client.crt"),
+		ClientKeyFile:  filepath.Join(dir, "client.key"),
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return certs, fmt.Errorf("failed to generate a CA key: %w", err)
+	}
+	caTemplate := certTemplate(1, "Test CA")
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate,
+		&caKey.PublicKey, caKey)
+	if err != nil {
+		return certs, fmt.Errorf("failed to create a CA certificate: %w", err)
+	}
+	caCert, err := x509.ParseCertificate(caDer)
+	if err != nil {
+		return certs, fmt.Errorf("failed to parse a CA certificate: %w", err)
+	}
+	if err = writePem(certs.CaFile, "CERTIFICATE", caDer); err != nil {
+		return certs, err
+	}
+
+	serverTemplate := certTemplate(2, "localhost")
+	serverTemplate.DNSNames = []string{"localhost"}
+	serverTemplate.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	serverTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if err = generateCert(serverTemplate, caCert, caKey,
+		certs.ServerCertFile, certs.ServerKeyFile); err != nil {
+		return certs, err
+	}
+
+	clientTemplate := certTemplate(3, "client")
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if err = generateCert(clientTemplate, caCert, caKey,
+		certs.ClientCertFile, certs.ClientKeyFile); err != nil {
+		return certs, err
+	}
+
+	return certs, nil
+}
+
+// ServerTLSConfig returns a TLS configuration for a server with the
+// generated server certificate. If verifyClient is true, the server requires
+// a client certificate signed by the generated CA.
+func (c Certs) ServerTLSConfig(verifyClient bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.ServerCertFile, c.ServerKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if verifyClient {
+		ca, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = x509.NewCertPool()
+		config.ClientCAs.AppendCertsFromPEM(ca)
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+func certTemplate(serial int64, commonName string) *x509.Certificate {
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject: pkix.Name{
+			Organization: []string{"go-tarantool tests"},
+			CommonName:   commonName,
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(certsValidity),
+		KeyUsage:  x509.KeyUsageDigitalSignature,
+	}
+}
+
+func generateCert(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey,
+	certFile, keyFile string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate a key: %w", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent,
+		&key.PublicKey, parentKey)
+	if err != nil {
+		return fmt.Errorf("failed to create a certificate: %w", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal a key: %w", err)
+	}
+
+	if err = writePem(certFile, "CERTIFICATE", der); err != nil {
+		return err
+	}
+	return writePem(keyFile, "EC PRIVATE KEY", keyDer)
+}
+
+func writePem(path, blockType string, der []byte) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tarantool
+
+import (
+	"context"
+)
+
+// TraceInfo describes a request passed to a Tracer.
+type TraceInfo struct {
+	// Request is the traced request.
+	Request Request
+	// Ctx is a context of the request. It is nil if the request has no
+	// context.
+	Ctx context.Context
+	// Conn is the connection that executes the request.
+	Conn *Connection
+	// RequestId is an ID of the request (IPROTO_SYNC).
+	RequestId uint32
+}
+
+// TraceFinish is called by a connection once a traced request is done.
+//
+// The err is nil on success. Client-side errors are passed as is, for
+// example, ClientError with ErrTimeouted code on a timeout. An error response
+// from Tarantool is passed as resp with a non-zero Header().Error and a nil
+// err.
+type TraceFinish func(resp Response, err error)
+
+// Tracer is the interface to trace requests of a Connection. It could be set
+// with Opts.Tracer.
+//
+// Methods and returned functions are called synchronously from goroutines of
+// the connection and its users, sometimes with internal locks held, so they
+// should be fast, non-blocking and safe for concurrent use.
+type Tracer interface {
+	// StartTrace is called when a request is passed to the connection. If
+	// it returns a non-nil function, the function is called exactly once
+	// when the request is done: a response is received, the request is
+	// failed, canceled or timed out.
+	StartTrace(info TraceInfo) TraceFinish
+}
+
+// startTrace starts tracing of the request if a tracer is set.
+func (conn *Connection) startTrace(fut *Future) {
+	if conn.opts.Tracer == nil {
+		return
+	}
+
+	finish := conn.opts.Tracer.StartTrace(TraceInfo{
+		Request:   fut.req,
+		Ctx:       fut.req.Ctx(),
+		Conn:      conn,
+		RequestId: fut.requestId,
+	})
+	if finish == nil {
+		return
+	}
+
+	fut.mutex.Lock()
+	if fut.isDone() {
+		resp, err := fut.resp, fut.err
+		fut.mutex.Unlock()
+		finish(resp, err)
+		return
+	}
+	fut.traceFinish = finish
+	fut.mutex.Unlock()
+}
+
+// finishTrace finishes tracing of the request if it has been started.
+func (conn *Connection) finishTrace(fut *Future) {
+	fut.mutex.Lock()
+	finish := fut.traceFinish
+	fut.traceFinish = nil
+	resp, err := fut.resp, fut.err
+	fut.mutex.Unlock()
+
+	if finish != nil {
+		finish(resp, err)
+	}
+}
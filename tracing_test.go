@@ -0,0 +1,116 @@
+package tarantool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+type mockTrace struct {
+	info     TraceInfo
+	finished int
+	resp     Response
+	err      error
+}
+
+type mockTracer struct {
+	mutex  sync.Mutex
+	traces []*mockTrace
+}
+
+func (t *mockTracer) StartTrace(info TraceInfo) TraceFinish {
+	trace := &mockTrace{info: info}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.traces = append(t.traces, trace)
+
+	return func(resp Response, err error) {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		trace.finished++
+		trace.resp = resp
+		trace.err = err
+	}
+}
+
+// find returns traces of requests with the type.
+func (t *mockTracer) find(rtype iproto.Type) []mockTrace {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var traces []mockTrace
+	for _, trace := range t.traces {
+		if trace.info.Request.Type() == rtype {
+			traces = append(traces, *trace)
+		}
+	}
+	return traces
+}
+
+func TestConnection_Tracer(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	server.HandleCall("ok", func(req *mockserver.Request) ([]interface{}, error) {
+		return []interface{}{true}, nil
+	})
+	server.HandleCall("fail", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, Error{Code: iproto.ER_PROC_LUA, Msg: "fail"}
+	})
+	server.HandleCall("hang", func(req *mockserver.Request) ([]interface{}, error) {
+		return nil, mockserver.ErrNoResponse
+	})
+
+	tracer := &mockTracer{}
+	conn := server.Connect(t, Opts{Tracer: tracer})
+
+	_, err := conn.Do(NewCallRequest("ok")).Get()
+	require.NoError(t, err)
+	_, err = conn.Do(NewCallRequest("fail")).Get()
+	require.Error(t, err)
+
+	hangCtx, hangCancel := context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer hangCancel()
+	_, err = conn.Do(NewCallRequest("hang").Context(hangCtx)).Get()
+	require.Error(t, err)
+
+	conn.Close()
+	_, err = conn.Do(NewPingRequest()).Get()
+	require.Error(t, err)
+
+	calls := tracer.find(iproto.IPROTO_CALL)
+	require.Len(t, calls, 3)
+	for _, trace := range calls {
+		assert.Equal(t, 1, trace.finished)
+		assert.Same(t, conn, trace.info.Conn)
+		assert.NotZero(t, trace.info.RequestId)
+	}
+
+	assert.NoError(t, calls[0].err)
+	require.NotNil(t, calls[0].resp)
+	assert.Equal(t, ErrorNo, calls[0].resp.Header().Error)
+
+	assert.NoError(t, calls[1].err)
+	require.NotNil(t, calls[1].resp)
+	assert.Equal(t, iproto.ER_PROC_LUA, calls[1].resp.Header().Error)
+
+	assert.Equal(t, hangCtx, calls[2].info.Ctx)
+	assert.Error(t, calls[2].err)
+	assert.Nil(t, calls[2].resp)
+
+	pings := tracer.find(iproto.IPROTO_PING)
+	require.Len(t, pings, 1)
+	assert.Equal(t, 1, pings[0].finished)
+	assert.ErrorIs(t, pings[0].err, ClientError{
+		Code: ErrConnectionClosed,
+		Msg:  "using closed connection",
+	})
+}
@@ -0,0 +1,108 @@
+package tarantool
+
+import (
+	"time"
+)
+
+// DoTyped performs the request with the doer and decodes data of the
+// response into a slice of T. The doer could be a Connection, a Stream or
+// any other Doer implementation.
+func DoTyped[T any](doer Doer, req Request) ([]T, error) {
+	return NewTypedFuture[T](doer.Do(req)).Get()
+}
+
+// GetOne performs the request with the doer and decodes the first element of
+// the response data into T. It returns false if the response data is empty.
+func GetOne[T any](doer Doer, req Request) (T, bool, error) {
+	return NewTypedFuture[T](doer.Do(req)).GetOne()
+}
+
+// DoTypedAsync performs the request with the doer asynchronously and returns
+// a TypedFuture for the response.
+func DoTypedAsync[T any](doer Doer, req Request) *TypedFuture[T] {
+	return NewTypedFuture[T](doer.Do(req))
+}
+
+// TypedFuture is a Future that decodes response data into a slice of T.
+type TypedFuture[T any] struct {
+	*Future
+}
+
+// NewTypedFuture wraps the future.
+func NewTypedFuture[T any](fut *Future) *TypedFuture[T] {
+	return &TypedFuture[T]{Future: fut}
+}
+
+// Get waits for the Future to be filled and decodes the response data into
+// a slice of T.
+func (fut *TypedFuture[T]) Get() ([]T, error) {
+	var res []T
+	if err := fut.Future.GetTyped(&res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetOne waits for the Future to be filled and decodes the first element of
+// the response data into T. It returns false if the response data is empty.
+func (fut *TypedFuture[T]) GetOne() (T, bool, error) {
+	var zero T
+	res, err := fut.Get()
+	if err != nil || len(res) == 0 {
+		return zero, false, err
+	}
+	return res[0], true, nil
+}
+
+// GetIterator returns an iterator for iterating through push messages and
+// a response with data decoded into a slice of T.
+//
+// Deprecated: the method will be removed in the next major version,
+// use Connector.NewWatcher() instead of box.session.push().
+func (fut *TypedFuture[T]) GetIterator() *TypedResponseIterator[T] {
+	return &TypedResponseIterator[T]{it: fut.Future.GetIterator()}
+}
+
+// TypedResponseIterator iterates over push messages and a response of a
+// TypedFuture.
+//
+// Deprecated: the type will be removed in the next major version,
+// use Connector.NewWatcher() instead of box.session.push().
+type TypedResponseIterator[T any] struct {
+	it TimeoutResponseIterator
+}
+
+// Next tries to switch to a next response and returns true if it exists.
+func (it *TypedResponseIterator[T]) Next() bool {
+	return it.it.Next()
+}
+
+// Value decodes data of the current response into a slice of T.
+func (it *TypedResponseIterator[T]) Value() ([]T, error) {
+	resp := it.it.Value()
+	if resp == nil {
+		return nil, it.it.Err()
+	}
+
+	var res []T
+	if err := resp.DecodeTyped(&res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsPush returns true if the current response is a push response.
+func (it *TypedResponseIterator[T]) IsPush() bool {
+	return it.it.IsPush()
+}
+
+// Err returns error if it happens.
+func (it *TypedResponseIterator[T]) Err() error {
+	return it.it.Err()
+}
+
+// WithTimeout allows to set up a timeout for the Next() call.
+func (it *TypedResponseIterator[T]) WithTimeout(timeout time.Duration) *TypedResponseIterator[T] {
+	it.it.WithTimeout(timeout)
+	return it
+}
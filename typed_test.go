@@ -0,0 +1,111 @@
+package tarantool_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-iproto"
+
+	. "github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/test_helpers/mockserver"
+)
+
+type typedTuple struct {
+	_msgpack struct{} `msgpack:",asArray"` //nolint: structcheck,unused
+	Id       uint
+	Name     string
+}
+
+// handleTypedTuples sets handlers that respond with typedTuple values.
+func handleTypedTuples(server *mockserver.Server) {
+	server.Handle(iproto.IPROTO_SELECT, func(req *mockserver.Request) ([]interface{}, error) {
+		if req.Limit() == 0 {
+			return []interface{}{}, nil
+		}
+		return []interface{}{
+			[]interface{}{1, "first"},
+			[]interface{}{2, "second"},
+		}, nil
+	})
+	server.HandleCall("push", func(req *mockserver.Request) ([]interface{}, error) {
+		for i := 1; i <= 2; i++ {
+			if err := req.Push([]interface{}{i, "push"}); err != nil {
+				return nil, err
+			}
+		}
+		return []interface{}{[]interface{}{3, "result"}}, nil
+	})
+}
+
+func TestDoTyped(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleTypedTuples(server)
+	conn := server.Connect(t, Opts{})
+
+	tuples, err := DoTyped[typedTuple](conn, NewSelectRequest(512).Index(0))
+	require.NoError(t, err)
+	require.Len(t, tuples, 2)
+	assert.Equal(t, uint(1), tuples[0].Id)
+	assert.Equal(t, "second", tuples[1].Name)
+
+	stream, err := conn.NewStream()
+	require.NoError(t, err)
+	tuples, err = DoTyped[typedTuple](stream, NewSelectRequest(512).Index(0))
+	require.NoError(t, err)
+	assert.Len(t, tuples, 2)
+
+	_, err = DoTyped[string](conn, NewSelectRequest(512).Index(0))
+	assert.Error(t, err)
+}
+
+func TestGetOne(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleTypedTuples(server)
+	conn := server.Connect(t, Opts{})
+
+	tuple, ok, err := GetOne[typedTuple](conn, NewSelectRequest(512).Index(0))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "first", tuple.Name)
+
+	tuple, ok, err = GetOne[typedTuple](conn, NewSelectRequest(512).Index(0).Limit(0))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, typedTuple{}, tuple)
+
+	_, ok, err = GetOne[typedTuple](conn, NewCallRequest("unknown"))
+	var tntErr Error
+	require.ErrorAs(t, err, &tntErr)
+	assert.Equal(t, iproto.ER_NO_SUCH_PROC, tntErr.Code)
+	assert.False(t, ok)
+}
+
+func TestTypedFuture(t *testing.T) {
+	server := mockserver.StartTest(t, mockserver.Opts{})
+	handleTypedTuples(server)
+	conn := server.Connect(t, Opts{})
+
+	fut := DoTypedAsync[typedTuple](conn, NewSelectRequest(512).Index(0))
+	tuples, err := fut.Get()
+	require.NoError(t, err)
+	assert.Len(t, tuples, 2)
+	_, err = fut.GetResponse()
+	require.NoError(t, err)
+
+	var names []string
+	var pushes []bool
+	it := DoTypedAsync[typedTuple](conn, NewCallRequest("push")).GetIterator().
+		WithTimeout(5 * time.Second)
+	for it.Next() {
+		tuples, err := it.Value()
+		require.NoError(t, err)
+		require.Len(t, tuples, 1)
+		names = append(names, tuples[0].Name)
+		pushes = append(pushes, it.IsPush())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"push", "push", "result"}, names)
+	assert.Equal(t, []bool{true, true, false}, pushes)
+}